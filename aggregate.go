@@ -0,0 +1,226 @@
+package sql
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+//aggregates are the labels and the function prefixes of the single value aggregates
+var aggregates = map[opType]struct {
+	label string
+	fn    string
+}{
+	opTypeSum:           {label: "sum", fn: "SUM("},
+	opTypeAvg:           {label: "avg", fn: "AVG("},
+	opTypeMax:           {label: "max", fn: "MAX("},
+	opTypeMin:           {label: "min", fn: "MIN("},
+	opTypeCountDistinct: {label: "count distinct", fn: "COUNT(DISTINCT "},
+}
+
+//AggregateFunc is an aggregate expression used in Select,several aggregates can be found in one round-trip by Aggregate
+//eg: db.Table("order").Select(As(sqlx.MaxOf("amount"), "max_amount"), As(sqlx.CountOf("*"), "total")).Aggregate(&stats)
+type AggregateFunc struct {
+	//fn is the function up to the column,eg: "SUM(",it may have arguments like percentile_cont
+	fn     string
+	column string
+	argc   []interface{}
+	filter *storage
+}
+
+//SumOf is SUM(column)
+func SumOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "SUM(", column: column}
+}
+
+//AvgOf is AVG(column)
+func AvgOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "AVG(", column: column}
+}
+
+//MaxOf is MAX(column)
+func MaxOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "MAX(", column: column}
+}
+
+//MinOf is MIN(column)
+func MinOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "MIN(", column: column}
+}
+
+//CountOf is COUNT(column),use "*" to count the rows
+func CountOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "COUNT(", column: column}
+}
+
+//CountDistinctOf is COUNT(DISTINCT column)
+func CountDistinctOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "COUNT(DISTINCT ", column: column}
+}
+
+func (p *PgTable) parseAggregateFunc(f *AggregateFunc) *storage {
+	column := f.column
+	if column != "*" {
+		column = p.parseIdent(column)
+	}
+	row := &storage{bucket: f.fn + column + ")", argc: f.argc}
+	if f.filter != nil {
+		row.bucket += " FILTER (WHERE " + f.filter.bucket + ")"
+		row.argc = append(append([]interface{}{}, f.argc...), f.filter.argc...)
+	}
+	return row
+}
+
+//Max finds the max value of the selected field,dest can be a pointer of int,float,string (decimal),time.Time,
+//a sql.Scanner or a pointer of pointer,the latter is set to nil if the set is empty (NULL),the others are set to zero
+//eg: var max *time.Time; err := db.Table("order").Select("created_date").Max(&max)
+func (p *PgTable) Max(max interface{}) error {
+	return p.aggregate(opTypeMax, max)
+}
+
+//Min finds the min value of the selected field,see Max for the supported destinations
+func (p *PgTable) Min(min interface{}) error {
+	return p.aggregate(opTypeMin, min)
+}
+
+//CountDistinct counts the distinct values of the selected field
+func (p *PgTable) CountDistinct(count interface{}) error {
+	return p.aggregate(opTypeCountDistinct, count)
+}
+
+//Aggregate scans the first row into the struct pointer,it is used to find several aggregates in one round-trip,
+//the fields are mapped by the json tags like Find,use pointer fields to keep NULL
+//eg:
+//	var stats struct {
+//		Total int64    `json:"total"`
+//		Avg   *float64 `json:"avg_amount"`
+//	}
+//	err := db.Table("order").Select(As(sqlx.CountOf("*"), "total"), As(sqlx.AvgOf("amount"), "avg_amount")).Aggregate(&stats)
+func (p *PgTable) Aggregate(dest interface{}) error {
+	if reflect.TypeOf(dest) == nil || reflect.TypeOf(dest).Kind() != reflect.Ptr || reflect.ValueOf(dest).Elem().Kind() != reflect.Struct {
+		p.clear()
+		return fmt.Errorf("aggregate:dest must be a struct pointer")
+	}
+	rows, err := p.open("aggregate")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		if err = rows.Scan(dest); err != nil {
+			return fmt.Errorf("aggregate:%w", err)
+		}
+	}
+	return rows.Err()
+}
+
+//aggregate runs the single value aggregate of the selected field and scans it into dest
+func (p *PgTable) aggregate(op opType, dest interface{}) error {
+	label := aggregates[op].label
+	if len(p.fields) != 1 {
+		p.clear()
+		return fmt.Errorf("%s:please use 'Select(fieldName)' to set the %s field", label, label)
+	}
+	if reflect.TypeOf(dest) == nil || reflect.TypeOf(dest).Kind() != reflect.Ptr || reflect.ValueOf(dest).IsNil() {
+		p.clear()
+		return fmt.Errorf("%s:dest must be a non-nil pointer", label)
+	}
+	sql := p.parseSQL(op)
+	defer p.clear()
+	if p.err != nil {
+		return fmt.Errorf("%s:%w", label, p.err)
+	}
+	stmt, err := p.executor().PrepareContext(p.ctx, sql.String())
+	if err != nil {
+		return fmt.Errorf("%s:prepare sql error:%w", label, err)
+	}
+	defer stmt.Close()
+	if err = scanValue(stmt.QueryRowContext(p.ctx, p.filler...), dest); err != nil {
+		return fmt.Errorf("%s:%w", label, err)
+	}
+	return nil
+}
+
+//scanValue scans a single value into dest,see scalarReceiver
+func scanValue(row *sql.Row, dest interface{}) error {
+	value := reflect.ValueOf(dest).Elem()
+	receiver := scalarReceiver(value.Type())
+	if err := row.Scan(receiver); err != nil {
+		return fmt.Errorf("query row context error:%w", err)
+	}
+	return setScalar(value, receiver)
+}
+
+//scalarReceiver returns the receiver of a column scanned into a value of typ,NULL is scanned as nil for pointers
+//and as zero for the others,the integers are scanned from the text so the decimals (eg: SUM/AVG of integers)
+//are rounded instead of failing
+func scalarReceiver(typ reflect.Type) interface{} {
+	if typ.Kind() == reflect.Ptr || reflect.PtrTo(typ).Implements(scannerType) {
+		return reflect.New(typ).Interface()
+	}
+	if isInteger(typ) {
+		return new(*string)
+	}
+	return reflect.New(reflect.PtrTo(typ)).Interface()
+}
+
+//setScalar sets the value scanned by the receiver of scalarReceiver
+func setScalar(value reflect.Value, receiver interface{}) error {
+	scanned := reflect.ValueOf(receiver).Elem()
+	if scanned.Type() == value.Type() {
+		value.Set(scanned)
+		return nil
+	}
+	if scanned.IsNil() {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+	if isInteger(value.Type()) {
+		return setInteger(value, scanned.Elem().String())
+	}
+	value.Set(scanned.Elem())
+	return nil
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+func isInteger(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+//setInteger parses the text of an integer or a decimal into the integer value,a decimal with a fractional part is an error
+func setInteger(value reflect.Value, text string) error {
+	unsigned := value.Kind() >= reflect.Uint && value.Kind() <= reflect.Uint64
+	if unsigned {
+		if n, err := strconv.ParseUint(text, 10, 64); err == nil && !value.OverflowUint(n) {
+			value.SetUint(n)
+			return nil
+		}
+	} else if n, err := strconv.ParseInt(text, 10, 64); err == nil && !value.OverflowInt(n) {
+		value.SetInt(n)
+		return nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return fmt.Errorf("can't convert '%s' to %s", text, value.Type())
+	}
+	if f != math.Trunc(f) {
+		return fmt.Errorf("can't convert '%s' to %s,it has a fractional part", text, value.Type())
+	}
+	switch {
+	case unsigned && f >= 0 && f < math.MaxUint64 && !value.OverflowUint(uint64(f)):
+		value.SetUint(uint64(f))
+	case !unsigned && f >= math.MinInt64 && f < math.MaxInt64 && !value.OverflowInt(int64(f)):
+		value.SetInt(int64(f))
+	default:
+		return fmt.Errorf("'%s' overflows %s", text, value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPgTable_aggregate_sql(t *testing.T) {
+	pg := fakePg()
+	tests := []struct {
+		name  string
+		table Table
+		op    opType
+		want  string
+	}{
+		{
+			name:  "avg",
+			table: pg.Table("order").Select("amount").Where("status=?", 1),
+			op:    opTypeAvg,
+			want:  `SELECT AVG(amount) FROM "order" WHERE status=$1`,
+		},
+		{
+			name:  "count distinct",
+			table: pg.Table("order").Select("user_id"),
+			op:    opTypeCountDistinct,
+			want:  `SELECT COUNT(DISTINCT user_id) FROM "order"`,
+		},
+		{
+			name:  "several aggregates",
+			table: pg.SetStrict(true).Table("order").Select(As(MaxOf("amount"), "max_amount"), As(CountOf("*"), "total")),
+			op:    opTypeQuery,
+			want:  `SELECT MAX("amount") AS "max_amount",COUNT(*) AS "total" FROM "order"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parse(tt.table, tt.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPgTable_Max(t *testing.T) {
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value driver.Value
+		dest  func() interface{}
+		want  interface{}
+		err   bool
+	}{
+		{name: "decimal into int", value: "2.5000", dest: func() interface{} { return new(int) }, err: true},
+		{name: "whole decimal into int", value: "2.0000", dest: func() interface{} { return new(int) }, want: 2},
+		{name: "decimal into float", value: "2.5000", dest: func() interface{} { return new(float64) }, want: 2.5},
+		{name: "decimal into string", value: "2.5000", dest: func() interface{} { return new(string) }, want: "2.5000"},
+		{name: "time", value: created, dest: func() interface{} { return new(time.Time) }, want: created},
+		{name: "null into int", value: nil, dest: func() interface{} { return new(int64) }, want: int64(0)},
+		{name: "null into nullable", value: nil, dest: func() interface{} { return new(*int64) }, want: (*int64)(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, _ := fakeDb(t, []string{"max"}, []driver.Value{tt.value})
+			dest := tt.dest()
+			err := pg.Table("order").Select("amount").Max(dest)
+			if (err != nil) != tt.err {
+				t.Fatalf("Max() error = %v, want error %v", err, tt.err)
+			}
+			if tt.err {
+				return
+			}
+			if got := reflect.ValueOf(dest).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Max() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPgTable_Aggregate(t *testing.T) {
+	pg, result := fakeDb(t, []string{"total", "avg_amount"}, []driver.Value{int64(0), nil})
+	var stats struct {
+		Total int64    `json:"total"`
+		Avg   *float64 `json:"avg_amount"`
+	}
+	err := pg.Table("order").Select(As(CountOf("*"), "total"), As(AvgOf("amount"), "avg_amount")).Aggregate(&stats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 0 || stats.Avg != nil {
+		t.Errorf("Aggregate() = %+v", stats)
+	}
+	want := `SELECT COUNT(*) AS "total",AVG(amount) AS "avg_amount" FROM "order"`
+	if len(result.queries) != 1 || result.queries[0] != want {
+		t.Errorf("Aggregate() queries = %v, want %v", result.queries, want)
+	}
+}
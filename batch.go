@@ -0,0 +1,143 @@
+package sql
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+//Batch is a batch of rows found by FindInBatches
+type Batch struct {
+	//Rows is the slice pointer of the rows,it has the same type as dest
+	Rows interface{}
+	//LastKey is the primary key of the last row,save it and use it as BatchConfig.After to resume,
+	//with BatchConfig.Workers > 1 the batches may finish out of order,so it is only a safe resume point
+	//after all the batches before it are finished (see Index)
+	LastKey interface{}
+	//Index is the number of the batch,it starts from 0
+	Index int
+}
+
+//BatchConfig is the optional config of FindInBatches
+type BatchConfig struct {
+	//Workers is the number of goroutines which run f,the batches may be finished out of order if it is greater than 1,
+	//so Batch.LastKey of a finished batch isn't a safe resume point until the batches with smaller Index are finished too
+	Workers int
+	//After resumes the iteration after the primary key
+	After interface{}
+}
+
+//FindInBatches walks the rows in batches of size ordered by the primary key (the field with `pri` tag),
+//the next batch is found by "pk > last key" instead of OFFSET,it stops at the first error returned by f
+//eg: err := db.Table("app").Where("type=?", "normal").FindInBatches(1000, &apps, func(batch *sqlx.Batch) error {
+//		return saveProgress(batch.LastKey)
+//	}, sqlx.BatchConfig{After: lastKey})
+func (p *PgTable) FindInBatches(size int64, dest interface{}, f func(batch *Batch) error, config ...BatchConfig) error {
+	defer p.clear()
+	if p.err != nil {
+		return fmt.Errorf("find in batches:%w", p.err)
+	}
+	if size < 1 {
+		return fmt.Errorf("find in batches:size must be greater than 0")
+	}
+	var conf BatchConfig
+	if len(config) != 0 {
+		conf = config[0]
+	}
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("find in batches:dest must be a slice pointer")
+	}
+	sliceType := destValue.Elem().Type()
+	elemType := sliceType.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("find in batches:dest must be a slice of struct")
+	}
+	priIdx, priColumn, err := primaryKey(elemType)
+	if err != nil {
+		return fmt.Errorf("find in batches:%w", err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		batches  chan *Batch
+	)
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	if conf.Workers > 1 {
+		batches = make(chan *Batch, conf.Workers)
+		for i := 0; i < conf.Workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for batch := range batches {
+					if failed() {
+						continue
+					}
+					if err := f(batch); err != nil {
+						setErr(err)
+					}
+				}
+			}()
+		}
+	}
+
+	last := conf.After
+	for index := 0; !failed(); index++ {
+		finder := p.clone()
+		finder.sort = []*storage{{storageType: storageTypeSort, bucket: priColumn + " " + string(Asc)}}
+		finder.offset = 0
+		finder.limit = size
+		if last != nil {
+			finder.where = andWhere(finder.where, &storage{
+				bucket: priColumn + " > ?",
+				argc:   []interface{}{last},
+			})
+		}
+		rows := destValue
+		if batches != nil {
+			rows = reflect.New(sliceType)
+		} else {
+			rows.Elem().Set(reflect.MakeSlice(sliceType, 0, int(size)))
+		}
+		if err := finder.Find(rows.Interface()); err != nil {
+			setErr(fmt.Errorf("find in batches:%w", err))
+			break
+		}
+		n := rows.Elem().Len()
+		if n == 0 {
+			break
+		}
+		lastRow := reflect.Indirect(rows.Elem().Index(n - 1))
+		last = lastRow.Field(priIdx).Interface()
+		batch := &Batch{Rows: rows.Interface(), LastKey: last, Index: index}
+		if batches != nil {
+			batches <- batch
+		} else if err := f(batch); err != nil {
+			setErr(err)
+		}
+		if int64(n) < size {
+			break
+		}
+	}
+	if batches != nil {
+		close(batches)
+		wg.Wait()
+	}
+	return firstErr
+}
@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestPgTable_FindInBatches(t *testing.T) {
+	type app struct {
+		Id   int64  `json:"id" pri:"true"`
+		Name string `json:"name"`
+	}
+	for _, workers := range []int{0, 2} {
+		pg, result := fakeDb(t, []string{"id", "name"},
+			[]driver.Value{int64(11), "a"},
+			[]driver.Value{int64(12), "b"},
+		)
+		var apps []app
+		var batches []*Batch
+		err := pg.Table("app").Where("type=?", "normal").FindInBatches(5, &apps, func(batch *Batch) error {
+			batches = append(batches, batch)
+			return nil
+		}, BatchConfig{Workers: workers, After: int64(10)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(batches) != 1 || batches[0].LastKey != int64(12) || len(*batches[0].Rows.(*[]app)) != 2 {
+			t.Fatalf("FindInBatches() batches = %+v", batches)
+		}
+		want := `SELECT * FROM "app" WHERE (type=$1) AND "id" > $2 ORDER BY "id" ASC LIMIT 5`
+		if result.queries[0] != want {
+			t.Errorf("FindInBatches() query = %s, want %s", result.queries[0], want)
+		}
+		if !reflect.DeepEqual(result.args[0], []driver.Value{"normal", int64(10)}) {
+			t.Errorf("FindInBatches() args = %v", result.args[0])
+		}
+	}
+}
+
+func TestPgTable_FindInBatches_next(t *testing.T) {
+	type app struct {
+		Id int64 `json:"id" pri:"true"`
+	}
+	for _, workers := range []int{0, 2} {
+		pg, result := fakeDb(t, []string{"id"})
+		result.queue = [][][]driver.Value{{{int64(1)}, {int64(2)}}, {{int64(3)}, {int64(4)}}, {{int64(5)}}}
+		var apps []app
+		var mu sync.Mutex
+		var batches []*Batch
+		//without workers dest is reused by the batches,so the rows are counted inside f
+		counts := map[int]int{}
+		err := pg.Table("app").Where("type=?", "normal").FindInBatches(2, &apps, func(batch *Batch) error {
+			mu.Lock()
+			defer mu.Unlock()
+			batches = append(batches, batch)
+			counts[batch.Index] = len(*batch.Rows.(*[]app))
+			return nil
+		}, BatchConfig{Workers: workers})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(batches, func(i, j int) bool { return batches[i].Index < batches[j].Index })
+		wantKeys := []interface{}{int64(2), int64(4), int64(5)}
+		wantRows := []int{2, 2, 1}
+		if len(batches) != len(wantKeys) {
+			t.Fatalf("FindInBatches(workers=%d) found %d batches, want %d", workers, len(batches), len(wantKeys))
+		}
+		for i, batch := range batches {
+			if batch.Index != i || batch.LastKey != wantKeys[i] || counts[i] != wantRows[i] {
+				t.Errorf("FindInBatches(workers=%d) batch %d = %+v", workers, i, batch)
+			}
+		}
+		want := []string{
+			`SELECT * FROM "app" WHERE type=$1 ORDER BY "id" ASC LIMIT 2`,
+			`SELECT * FROM "app" WHERE (type=$1) AND "id" > $2 ORDER BY "id" ASC LIMIT 2`,
+			`SELECT * FROM "app" WHERE (type=$1) AND "id" > $2 ORDER BY "id" ASC LIMIT 2`,
+		}
+		if !reflect.DeepEqual(result.queries, want) {
+			t.Errorf("FindInBatches(workers=%d) queries = %v, want %v", workers, result.queries, want)
+		}
+		wantArgs := [][]driver.Value{{"normal"}, {"normal", int64(2)}, {"normal", int64(4)}}
+		if !reflect.DeepEqual(result.args, wantArgs) {
+			t.Errorf("FindInBatches(workers=%d) args = %v, want %v", workers, result.args, wantArgs)
+		}
+	}
+}
+
+func TestPgTable_FindInBatches_whereOr(t *testing.T) {
+	type app struct {
+		Id int64 `json:"id" pri:"true"`
+	}
+	pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(11)})
+	var apps []app
+	err := pg.Table("app").Where("type=?", "normal").WhereOr("type=?", "vip").FindInBatches(5, &apps, func(batch *Batch) error {
+		return nil
+	}, BatchConfig{After: int64(10)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT * FROM "app" WHERE (type=$1 OR type=$2) AND "id" > $3 ORDER BY "id" ASC LIMIT 5`
+	if result.queries[0] != want {
+		t.Errorf("FindInBatches() query = %s, want %s", result.queries[0], want)
+	}
+}
+
+func TestPgTable_FindInBatches_withoutPri(t *testing.T) {
+	var rows []struct {
+		Name string `json:"name"`
+	}
+	err := fakePg().Table("app").FindInBatches(5, &rows, func(batch *Batch) error { return nil })
+	if err == nil {
+		t.Error("FindInBatches() want error without primary key")
+	}
+}
@@ -0,0 +1,52 @@
+package sql
+
+import (
+	"fmt"
+)
+
+//Union combines the query with another one by UNION,the Sort/Offset/Limit of the current builder apply to the combined result
+//eg: db.Table("app").Select("id,name").Union(db.Table("app_archive").Select("id,name")).Sort("id", "desc").Find(&apps)
+func (p *PgTable) Union(query Query) Query {
+	return p.compose("UNION", query)
+}
+
+//UnionAll combines the query with another one by UNION ALL,the duplicate rows are kept
+func (p *PgTable) UnionAll(query Query) Query {
+	return p.compose("UNION ALL", query)
+}
+
+//Intersect keeps the rows which are returned by both queries
+func (p *PgTable) Intersect(query Query) Query {
+	return p.compose("INTERSECT", query)
+}
+
+//Except keeps the rows which are not returned by the other query
+func (p *PgTable) Except(query Query) Query {
+	return p.compose("EXCEPT", query)
+}
+
+func (p *PgTable) compose(op string, query Query) Query {
+	if _, ok := subqueryOf(query); !ok {
+		p.setErr(fmt.Errorf("%s:query must be a Table/Query builder", op))
+		return p.query
+	}
+	p.compound = append(p.compound, &storage{
+		storageType: storageTypeCompound,
+		bucket:      op,
+		argc:        []interface{}{query},
+	})
+	return p.query
+}
+
+//parseCompound appends the combined queries to the current one,the queries are combined from left to right
+//eg: a.Union(b).Intersect(c) => (a UNION (b)) INTERSECT (c)
+func (p *PgTable) parseCompound(core string) string {
+	for i, row := range p.meta.compound {
+		if i != 0 && row.bucket != p.meta.compound[i-1].bucket {
+			core = "(" + core + ")"
+		}
+		sub, _ := subqueryOf(row.argc[0])
+		core += " " + row.bucket + " (" + p.parseSubquery(sub) + ")"
+	}
+	return core
+}
@@ -0,0 +1,53 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_Union(t *testing.T) {
+	tests := []struct {
+		name   string
+		build  func(pg SQL) Query
+		want   string
+		filler []interface{}
+	}{
+		{
+			name: "union all with shared sort and limit",
+			build: func(pg SQL) Query {
+				live := pg.Table("app").Select("id,name").Where("type=?", "normal")
+				archive := pg.Table("app_archive").Select("id,name").Where("type=?", "old")
+				return live.UnionAll(archive).Sort("id", "desc").Limit(10)
+			},
+			want:   `SELECT id,name FROM "app" WHERE type=$1 UNION ALL (SELECT id,name FROM "app_archive" WHERE type=$2) ORDER BY id DESC LIMIT 10`,
+			filler: []interface{}{"normal", "old"},
+		},
+		{
+			name: "left to right",
+			build: func(pg SQL) Query {
+				a := pg.Table("a").Select("id")
+				return a.Union(pg.Table("b").Select("id")).
+					Intersect(pg.Table("c").Select("id").Where("id>?", 1)).
+					Except(pg.Table("d").Select("id"))
+			},
+			want: `((SELECT id FROM "a" UNION (SELECT id FROM "b")) INTERSECT (SELECT id FROM "c" WHERE id>$1)) ` +
+				`EXCEPT (SELECT id FROM "d")`,
+			filler: []interface{}{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := tt.build(fakePg()).(*PgQuery)
+			got, filler, err := parse(query.table, opTypeQuery)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//cte is a common table expression of the WITH clause
+type cte struct {
+	name      string
+	recursive bool
+	//bodies has one statement,or the anchor and the recursive term of WITH RECURSIVE
+	bodies []interface{}
+}
+
+//statement is a data-modifying statement used as the body of a cte,it is created by InsertStmt/UpdateStmt/DeleteStmt
+type statement struct {
+	op    opType
+	table *PgTable
+	dest  interface{}
+}
+
+//InsertStmt uses the builder as "INSERT INTO ... VALUES ..." inside a WITH clause,use Returning(...) to output the rows
+func InsertStmt(table Table, dest interface{}) interface{} {
+	return &statement{op: opTypeCreate, table: table.(*PgTable), dest: dest}
+}
+
+//UpdateStmt uses the builder as "UPDATE ... SET ..." inside a WITH clause,use Returning(...) to output the rows
+func UpdateStmt(table Table, dest interface{}) interface{} {
+	return &statement{op: opTypeSave, table: table.(*PgTable), dest: dest}
+}
+
+//DeleteStmt uses the builder as "DELETE FROM ..." inside a WITH clause,use Returning(...) to output the rows
+func DeleteStmt(table Table) interface{} {
+	return &statement{op: opTypeDelete, table: table.(*PgTable)}
+}
+
+//With prepends a common table expression to the statement,body is a Table/Query builder or InsertStmt/UpdateStmt/DeleteStmt
+//eg: db.Table("moved").With("moved", DeleteStmt(db.Table("queue").Where("done=?", true).Returning("*"))).Find(&rows)
+func (p *PgTable) With(name string, body interface{}) Table {
+	p.with = append(p.with, &cte{name: name, bodies: []interface{}{body}})
+	return p
+}
+
+//WithRecursive prepends a recursive common table expression: name AS (anchor UNION ALL recursive)
+//the recursive term can refer to the cte by its name
+func (p *PgTable) WithRecursive(name string, anchor, recursive interface{}) Table {
+	p.with = append(p.with, &cte{name: name, recursive: true, bodies: []interface{}{anchor, recursive}})
+	return p
+}
+
+//Returning adds RETURNING to the INSERT/UPDATE/DELETE statement
+func (p *PgTable) Returning(fields ...interface{}) Table {
+	p.returning = p.parseColumns(fields)
+	if len(p.returning) == 0 {
+		p.returning = []*storage{{bucket: "*"}}
+	}
+	return p
+}
+
+func (p *PgTable) parseWith() (cond bytes.Buffer) {
+	if len(p.meta.with) == 0 {
+		return
+	}
+	cond.WriteString("WITH ")
+	for _, c := range p.meta.with {
+		if c.recursive {
+			cond.WriteString("RECURSIVE ")
+			break
+		}
+	}
+	//the names of the ctes are in scope of the bodies and the statement
+	names := map[string]bool{}
+	for name := range p.cteNames {
+		names[name] = true
+	}
+	for _, c := range p.meta.with {
+		if parts, err := splitIdent(c.name); err == nil && len(parts) == 1 {
+			names[parts[0]] = true
+		}
+	}
+	p.cteNames = names
+	for i, c := range p.meta.with {
+		if i != 0 {
+			cond.WriteString(", ")
+		}
+		parts, err := splitIdent(c.name)
+		if err != nil || len(parts) != 1 || parts[0] == "*" {
+			p.setErr(fmt.Errorf("with:invalid name '%s'", c.name))
+			continue
+		}
+		cond.WriteString(quotePart(parts[0]))
+		cond.WriteString(" AS (")
+		for j, body := range c.bodies {
+			if j != 0 {
+				cond.WriteString(" UNION ALL ")
+			}
+			cond.WriteString(p.parseStatement(body))
+		}
+		cond.WriteString(")")
+	}
+	cond.WriteString(" ")
+	return
+}
+
+//parseStatement renders the body of a cte
+func (p *PgTable) parseStatement(body interface{}) string {
+	if sub, ok := subqueryOf(body); ok {
+		return p.parseSubquery(sub)
+	}
+	stmt, ok := body.(*statement)
+	if !ok {
+		p.setErr(fmt.Errorf("with:unsupported statement type %T", body))
+		return ""
+	}
+	return p.parseNested(stmt.table, func(sub *PgTable) (string, error) {
+		switch stmt.op {
+		case opTypeCreate:
+			return sub.buildSave(stmt.dest)
+		case opTypeSave:
+			return sub.buildUpdate(stmt.dest)
+		default:
+			return sub.buildDelete()
+		}
+	})
+}
+
+func (p *PgTable) parseReturning() string {
+	if len(p.meta.returning) == 0 {
+		return ""
+	}
+	return " RETURNING " + p.parseStorages(p.meta.returning)
+}
@@ -0,0 +1,120 @@
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_With(t *testing.T) {
+	type archive struct {
+		Id   int    `json:"id" pri:"true"`
+		Name string `json:"name"`
+	}
+	tests := []struct {
+		name   string
+		schema string
+		build  func(pg SQL) (Table, opType)
+		want   string
+		filler []interface{}
+	}{
+		{
+			name: "select with cte",
+			build: func(pg SQL) (Table, opType) {
+				recent := pg.Table("app").Select("id", "type").Where("created_date>?", "2022-01-01")
+				return pg.Table("recent").With("recent", recent).Where("type=?", "normal"), opTypeQuery
+			},
+			want:   `WITH "recent" AS (SELECT id,type FROM "app" WHERE created_date>$1) SELECT * FROM "recent" WHERE type=$2`,
+			filler: []interface{}{"2022-01-01", "normal"},
+		},
+		{
+			name: "recursive",
+			build: func(pg SQL) (Table, opType) {
+				anchor := pg.Table("category").Select("id", "parent_id").Where("id=?", 1)
+				recursive := pg.Table("category").Select("category.id", "category.parent_id").
+					Where("category.parent_id IN ?", pg.Table("tree").Select("id"))
+				return pg.Table("tree").WithRecursive("tree", anchor, recursive), opTypeQuery
+			},
+			want: `WITH RECURSIVE "tree" AS (SELECT id,parent_id FROM "category" WHERE id=$1 UNION ALL ` +
+				`SELECT category.id,category.parent_id FROM "category" WHERE category.parent_id IN (SELECT id FROM "tree")) SELECT * FROM "tree"`,
+			filler: []interface{}{1},
+		},
+		{
+			name: "data-modifying cte",
+			build: func(pg SQL) (Table, opType) {
+				moved := pg.Table("queue").Where("done=?", true).Returning("id", "name")
+				return pg.Table("moved").With("moved", DeleteStmt(moved)).Where("id>?", 10), opTypeQuery
+			},
+			want:   `WITH "moved" AS (DELETE FROM "queue" WHERE done=$1 RETURNING id,name) SELECT * FROM "moved" WHERE id>$2`,
+			filler: []interface{}{true, 10},
+		},
+		{
+			name: "delete with cte",
+			build: func(pg SQL) (Table, opType) {
+				inserted := pg.Table("archive").Returning("id")
+				return pg.Table("queue").
+					With("inserted", InsertStmt(inserted, &archive{Name: "a"})).
+					Where("id IN ?", pg.Table("inserted").Select("id")), opTypeDelete
+			},
+			want:   `WITH "inserted" AS (INSERT INTO "archive"(id,name) VALUES (DEFAULT,$1) RETURNING id) DELETE FROM "queue" WHERE id IN (SELECT id FROM "inserted")`,
+			filler: []interface{}{"a"},
+		},
+		{
+			name:   "default schema",
+			schema: "billing",
+			build: func(pg SQL) (Table, opType) {
+				moved := pg.Table("queue").Where("done=?", true).Returning("id")
+				return pg.Table("moved").With("moved", DeleteStmt(moved)), opTypeQuery
+			},
+			want:   `WITH "moved" AS (DELETE FROM "billing"."queue" WHERE done=$1 RETURNING id) SELECT * FROM "moved"`,
+			filler: []interface{}{true},
+		},
+		{
+			name:   "default schema recursive",
+			schema: "billing",
+			build: func(pg SQL) (Table, opType) {
+				anchor := pg.Table("category").Select("id").Where("id=?", 1)
+				recursive := pg.Table("category").Select("category.id").
+					Where("category.parent_id IN ?", pg.Table("tree").Select("id"))
+				return pg.Table("tree").WithRecursive("tree", anchor, recursive), opTypeQuery
+			},
+			want: `WITH RECURSIVE "tree" AS (SELECT id FROM "billing"."category" WHERE id=$1 UNION ALL ` +
+				`SELECT category.id FROM "billing"."category" WHERE category.parent_id IN (SELECT id FROM "tree")) SELECT * FROM "tree"`,
+			filler: []interface{}{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := fakePg()
+			pg.SetSchema(tt.schema)
+			table, op := tt.build(pg)
+			got, filler, err := parse(table, op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
+
+func TestPgTable_buildUpdate(t *testing.T) {
+	pg := fakePg()
+	table := pg.Table("app").
+		With("old", pg.Table("app_old").Select("id").Where("type=?", "old")).
+		Where("id IN ?", pg.Table("old").Select("id")).(*PgTable)
+	got, err := table.buildUpdate(&map[string]interface{}{"name": "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `WITH "old" AS (SELECT id FROM "app_old" WHERE type=$1) UPDATE "app" SET ("name") = ($2) WHERE id IN (SELECT id FROM "old")`
+	if got != want {
+		t.Errorf("buildUpdate() = %s, want %s", got, want)
+	}
+	if !reflect.DeepEqual(table.filler, []interface{}{"old", "x"}) {
+		t.Errorf("filler = %v", table.filler)
+	}
+}
@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+//cursorSeq makes the names of the server-side cursors unique
+var cursorSeq uint64
+
+//serverCursor is a cursor declared by DECLARE ... CURSOR FOR ...
+type serverCursor struct {
+	ctx  context.Context
+	conn executor
+	//tx is the transaction opened for the cursor,it is nil if the cursor is declared inside Transaction(...)
+	tx      *sql.Tx
+	name    string
+	batch   int64
+	fetched int64
+}
+
+//Cursor turns on the server-side cursor mode,Rows/Each/Find fetch the rows batch by batch by
+//DECLARE CURSOR/FETCH/CLOSE,a transaction is opened if the builder isn't inside Transaction(...)
+//eg: err := db.Table("log").Cursor(1000).Each(&log, func() error { return export(log) })
+func (p *PgTable) Cursor(batch int64) Query {
+	if batch < 1 {
+		p.setErr(fmt.Errorf("cursor:batch must be greater than 0"))
+		return p.query
+	}
+	p.cursorBatch = batch
+	return p.query
+}
+
+//openCursor declares the cursor for the query and fetches the first batch
+func (p *PgTable) openCursor(label, query string) (*Rows, error) {
+	cursor := &serverCursor{
+		ctx:   p.ctx,
+		conn:  p.tx,
+		name:  quotePart("sqlx_cursor_" + strconv.FormatUint(atomic.AddUint64(&cursorSeq, 1), 10)),
+		batch: p.cursorBatch,
+	}
+	if p.tx == nil {
+		tx, err := p.db.BeginTx(p.ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s:begin error:%w", label, err)
+		}
+		cursor.tx = tx
+		cursor.conn = tx
+	}
+	if _, err := cursor.conn.ExecContext(p.ctx, "DECLARE "+cursor.name+" NO SCROLL CURSOR FOR "+query, p.filler...); err != nil {
+		cursor.end(true)
+		return nil, fmt.Errorf("%s:declare cursor error:%w", label, err)
+	}
+	rows, err := cursor.fetch()
+	if err != nil {
+		cursor.close(true)
+		return nil, fmt.Errorf("%s:%w", label, err)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		cursor.close(true)
+		return nil, fmt.Errorf("%s:%w", label, err)
+	}
+	return &Rows{rows: rows, mapper: &mapper{columns: columns}, label: label, cursor: cursor}, nil
+}
+
+//fetch fetches the next batch of the cursor
+func (c *serverCursor) fetch() (*sql.Rows, error) {
+	c.fetched = 0
+	rows, err := c.conn.QueryContext(c.ctx, "FETCH FORWARD "+strconv.FormatInt(c.batch, 10)+" FROM "+c.name)
+	if err != nil {
+		return nil, fmt.Errorf("fetch cursor error:%w", err)
+	}
+	return rows, nil
+}
+
+//close closes the cursor and ends the transaction opened for it,the transaction is rolled back if abort is true
+//(the cursor is dropped by the rollback) or if the cursor can't be closed
+func (c *serverCursor) close(abort bool) error {
+	if abort && c.tx != nil {
+		return c.end(true)
+	}
+	_, err := c.conn.ExecContext(c.ctx, "CLOSE "+c.name)
+	if err != nil {
+		err = fmt.Errorf("close cursor error:%w", err)
+	}
+	if endErr := c.end(abort || err != nil); err == nil {
+		err = endErr
+	}
+	return err
+}
+
+//end commits the transaction opened for the cursor,or rolls it back if abort is true
+func (c *serverCursor) end(abort bool) error {
+	if c.tx == nil {
+		return nil
+	}
+	if abort {
+		if err := c.tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			return fmt.Errorf("rollback error:%w", err)
+		}
+		return nil
+	}
+	if err := c.tx.Commit(); err != nil && err != sql.ErrTxDone {
+		return fmt.Errorf("commit error:%w", err)
+	}
+	return nil
+}
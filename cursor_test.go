@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPgTable_Cursor(t *testing.T) {
+	pg, result := fakeDb(t, []string{"id", "name"},
+		[]driver.Value{int64(1), "a"},
+		[]driver.Value{int64(2), "b"},
+		[]driver.Value{int64(3), "c"},
+		[]driver.Value{int64(4), "d"},
+	)
+	var app fakeApp
+	var ids []int64
+	err := pg.Table("app").Where("id>?", 0).Cursor(2).Each(&app, func() error {
+		ids = append(ids, app.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 4 || ids[0] != 1 || ids[3] != 4 {
+		t.Errorf("Each() ids = %v", ids)
+	}
+	var fetches int
+	for _, query := range result.queries {
+		if strings.HasPrefix(query, "FETCH FORWARD 2 FROM") {
+			fetches++
+		}
+	}
+	if !strings.HasPrefix(result.queries[0], `DECLARE "sqlx_cursor_`) ||
+		!strings.HasSuffix(result.queries[0], `NO SCROLL CURSOR FOR SELECT * FROM "app" WHERE id>$1`) {
+		t.Errorf("declare = %s", result.queries[0])
+	}
+	if fetches != 3 {
+		t.Errorf("fetches = %d, want 3", fetches)
+	}
+	if last := result.queries[len(result.queries)-1]; !strings.HasPrefix(last, `CLOSE "sqlx_cursor_`) {
+		t.Errorf("the cursor isn't closed,last query = %s", last)
+	}
+	if result.commits != 1 || result.rollbacks != 0 {
+		t.Errorf("commits = %d, rollbacks = %d, want 1 commit", result.commits, result.rollbacks)
+	}
+}
+
+func TestPgTable_Cursor_rollback(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed string
+		id     driver.Value
+	}{
+		{name: "declare", failed: "DECLARE", id: int64(1)},
+		{name: "fetch", failed: "FETCH", id: int64(1)},
+		{name: "scan", id: "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, result := fakeDb(t, []string{"id", "name"}, []driver.Value{tt.id, "a"})
+			if tt.failed != "" {
+				result.failed = map[string]error{tt.failed: errors.New("failed")}
+			}
+			var apps []fakeApp
+			if err := pg.Table("app").Cursor(2).Find(&apps); err == nil {
+				t.Fatal("Find() error = nil, want error")
+			}
+			if result.commits != 0 || result.rollbacks != 1 {
+				t.Errorf("commits = %d, rollbacks = %d, want 1 rollback", result.commits, result.rollbacks)
+			}
+			for _, query := range result.queries {
+				if strings.HasPrefix(query, "CLOSE") {
+					t.Errorf("the cursor is closed after the rollback: %s", query)
+				}
+			}
+		})
+	}
+}
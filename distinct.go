@@ -0,0 +1,64 @@
+package sql
+
+import (
+	"strings"
+)
+
+//Distinct removes the duplicate rows: SELECT DISTINCT ...
+func (p *PgTable) Distinct() Table {
+	p.distinct = true
+	p.distinctOn = nil
+	return p
+}
+
+//DistinctOn keeps the first row of each group of the columns: SELECT DISTINCT ON (...) ...
+//the first row is decided by Sort,the columns are added to the front of ORDER BY if they are not sorted yet
+//the columns are the same as Group,eg: db.Table("price").DistinctOn("product_id").Sort("created_date", "desc").Find(&latest)
+func (p *PgTable) DistinctOn(columns ...interface{}) Table {
+	p.distinct = false
+	p.distinctOn = p.parseGroup(columns)
+	return p
+}
+
+func (p *PgTable) parseDistinct() string {
+	if len(p.meta.distinctOn) != 0 {
+		return "DISTINCT ON (" + p.parseStorages(p.meta.distinctOn) + ") "
+	}
+	if p.meta.distinct {
+		return "DISTINCT "
+	}
+	return ""
+}
+
+//sortWithDistinctOn makes the leftmost ORDER BY items match the DISTINCT ON columns,which is required by postgres
+func (p *PgTable) sortWithDistinctOn() []*storage {
+	if len(p.meta.distinctOn) == 0 || len(p.meta.sort) == 0 {
+		return p.meta.sort
+	}
+	pending := make(map[string]bool)
+	for _, column := range p.meta.distinctOn {
+		pending[column.bucket] = true
+	}
+	var sort []*storage
+	var i int
+	for ; i < len(p.meta.sort) && pending[sortExprOf(p.meta.sort[i])]; i++ {
+		delete(pending, sortExprOf(p.meta.sort[i]))
+		sort = append(sort, p.meta.sort[i])
+	}
+	for _, column := range p.meta.distinctOn {
+		if pending[column.bucket] {
+			delete(pending, column.bucket)
+			sort = append(sort, &storage{storageType: storageTypeSort, bucket: column.bucket, argc: column.argc})
+		}
+	}
+	return append(sort, p.meta.sort[i:]...)
+}
+
+//sortExprOf returns the expression of the ORDER BY item without the direction and the nulls order
+func sortExprOf(row *storage) string {
+	expr := row.bucket
+	for _, suffix := range []string{" " + string(NullsFirst), " " + string(NullsLast), " " + string(Asc), " " + string(Desc)} {
+		expr = strings.TrimSuffix(expr, suffix)
+	}
+	return expr
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"testing"
+)
+
+func TestPgTable_Distinct(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(pg SQL) Table
+		op    opType
+		want  string
+	}{
+		{
+			name: "distinct",
+			build: func(pg SQL) Table {
+				return pg.Table("app").Select("type").Distinct()
+			},
+			op:   opTypeQuery,
+			want: `SELECT DISTINCT type FROM "app"`,
+		},
+		{
+			name: "distinct on adds the missing sort columns",
+			build: func(pg SQL) Table {
+				table := pg.Table("price").DistinctOn("product_id")
+				table.Sort("created_date", "desc")
+				return table
+			},
+			op:   opTypeQuery,
+			want: `SELECT DISTINCT ON (product_id) * FROM "price" ORDER BY product_id, created_date DESC`,
+		},
+		{
+			name: "distinct on keeps the matching sort columns",
+			build: func(pg SQL) Table {
+				table := pg.Table("price").DistinctOn("shop_id", "product_id")
+				table.Sort("product_id", "desc").Sort("created_date", "desc")
+				return table
+			},
+			op:   opTypeQuery,
+			want: `SELECT DISTINCT ON (shop_id,product_id) * FROM "price" ORDER BY product_id DESC, shop_id, created_date DESC`,
+		},
+		{
+			name: "distinct on column list",
+			build: func(pg SQL) Table {
+				table := pg.Table("price").DistinctOn("a, b")
+				table.Sort("a", "desc").Sort("c", "asc")
+				return table
+			},
+			op:   opTypeQuery,
+			want: `SELECT DISTINCT ON (a,b) * FROM "price" ORDER BY a DESC, b, c ASC`,
+		},
+		{
+			name: "count distinct",
+			build: func(pg SQL) Table {
+				return pg.Table("app").Select("type").Distinct().Where("id>?", 1)
+			},
+			op:   opTypeCount,
+			want: `SELECT COUNT(*) FROM (SELECT DISTINCT type FROM "app" WHERE id>$1) AS "t"`,
+		},
+		{
+			name: "count distinct on",
+			build: func(pg SQL) Table {
+				return pg.Table("price").DistinctOn("product_id")
+			},
+			op:   opTypeCount,
+			want: `SELECT COUNT(*) FROM (SELECT DISTINCT ON (product_id) * FROM "price") AS "t"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parse(tt.build(fakePg()), tt.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+//explainPlan is the root of EXPLAIN (FORMAT JSON)
+type explainPlan struct {
+	Plan struct {
+		Rows float64 `json:"Plan Rows"`
+	} `json:"Plan"`
+}
+
+//EstimateCount estimates the count quickly without scanning the table,the statistics (pg_class.reltuples) are used
+//if there is no condition,otherwise the row estimate of EXPLAIN is used,so the result depends on ANALYZE
+//eg: err := db.Table("log").EstimateCount(&count)
+func (p *PgTable) EstimateCount(count *int64) error {
+	defer p.clear()
+	if p.err != nil {
+		return fmt.Errorf("estimate count:%w", p.err)
+	}
+	if p.filtered() {
+		return p.explainCount(count)
+	}
+	tableName := p.parseTableName()
+	if p.err != nil {
+		return fmt.Errorf("estimate count:%w", p.err)
+	}
+	var reltuples int64
+	err := p.executor().QueryRowContext(p.ctx, "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)", tableName.String()).Scan(&reltuples)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("estimate count:table %s not found", tableName.String())
+	}
+	if err != nil {
+		return fmt.Errorf("estimate count:query row context error:%w", err)
+	}
+	//reltuples is -1 if the table has never been analyzed
+	if reltuples < 0 {
+		p.filler, p.storageCursor = nil, 0
+		return p.explainCount(count)
+	}
+	*count = reltuples
+	return nil
+}
+
+//SmartCount estimates the count first and counts exactly if the estimate is less than threshold,
+//so the small results are exact and the large ones are fast
+//eg: err := db.Table("log").Where("level=?", "error").SmartCount(100000, &count)
+func (p *PgTable) SmartCount(threshold int64, count *int64) error {
+	defer p.clear()
+	if err := p.clone().EstimateCount(count); err != nil {
+		return fmt.Errorf("smart count:%w", err)
+	}
+	if *count >= threshold {
+		return nil
+	}
+	if err := p.clone().Count(count); err != nil {
+		return fmt.Errorf("smart count:%w", err)
+	}
+	return nil
+}
+
+//filtered reports whether the rows of the query are not all the rows of the table
+func (p *PgTable) filtered() bool {
+	return len(p.where) != 0 || p.from != nil || len(p.with) != 0 || len(p.compound) != 0 || len(p.group) != 0 ||
+		len(p.having) != 0 || p.distinct || len(p.distinctOn) != 0 || p.sample != nil || p.raw != nil ||
+		p.limit != 0 || p.offset != 0
+}
+
+//explainCount reads the estimated rows of the query from EXPLAIN,OFFSET/LIMIT are kept as they limit the rows
+func (p *PgTable) explainCount(count *int64) error {
+	p.sort = nil
+	p.lock = nil
+	sql := p.parseSQL(opTypeQuery)
+	if p.err != nil {
+		return fmt.Errorf("estimate count:%w", p.err)
+	}
+	var plan string
+	if err := p.executor().QueryRowContext(p.ctx, "EXPLAIN (FORMAT JSON) "+sql.String(), p.filler...).Scan(&plan); err != nil {
+		return fmt.Errorf("estimate count:explain error:%w", err)
+	}
+	var plans []explainPlan
+	if err := json.Unmarshal([]byte(plan), &plans); err != nil || len(plans) == 0 {
+		return fmt.Errorf("estimate count:invalid plan '%s'", plan)
+	}
+	*count = int64(plans[0].Plan.Rows)
+	return nil
+}
@@ -0,0 +1,65 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_EstimateCount(t *testing.T) {
+	t.Run("reltuples", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"reltuples"}, []driver.Value{int64(200000000)})
+		var count int64
+		if err := pg.Table("billing.invoices").EstimateCount(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 200000000 {
+			t.Errorf("EstimateCount() = %v", count)
+		}
+		if want := []driver.Value{`"billing"."invoices"`}; !reflect.DeepEqual(result.args[0], want) {
+			t.Errorf("EstimateCount() args = %v, want %v", result.args[0], want)
+		}
+	})
+	t.Run("explain", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"QUERY PLAN"}, []driver.Value{`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 1234}}]`})
+		var count int64
+		if err := pg.Table("log").Where("level=?", "error").Sort("id", "desc").Limit(10).EstimateCount(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 1234 {
+			t.Errorf("EstimateCount() = %v", count)
+		}
+		if want := `EXPLAIN (FORMAT JSON) SELECT * FROM "log" WHERE level=$1 LIMIT 10`; result.queries[0] != want {
+			t.Errorf("EstimateCount() query = %v, want %v", result.queries[0], want)
+		}
+	})
+	t.Run("offset", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"QUERY PLAN"}, []driver.Value{`[{"Plan": {"Node Type": "Limit", "Plan Rows": 10}}]`})
+		var count int64
+		if err := pg.Table("log").Offset(100).Limit(10).EstimateCount(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != 10 {
+			t.Errorf("EstimateCount() = %v", count)
+		}
+		if want := `EXPLAIN (FORMAT JSON) SELECT * FROM "log" OFFSET 100 LIMIT 10`; result.queries[0] != want {
+			t.Errorf("EstimateCount() query = %v, want %v", result.queries[0], want)
+		}
+	})
+}
+
+func TestPgTable_SmartCount(t *testing.T) {
+	pg, result := fakeDb(t, []string{"QUERY PLAN"}, []driver.Value{`[{"Plan": {"Plan Rows": 10}}]`})
+	result.prefixed = map[string]*fakeResult{"SELECT COUNT(*)": {columns: []string{"count"}, rows: [][]driver.Value{{int64(7)}}}}
+	var count int64
+	if err := pg.Table("log").Where("level=?", "error").SmartCount(100, &count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 7 {
+		t.Errorf("SmartCount() = %v, want 7", count)
+	}
+	want := []string{`EXPLAIN (FORMAT JSON) SELECT * FROM "log" WHERE level=$1`, `SELECT COUNT(*) FROM "log" WHERE level=$1`}
+	if !reflect.DeepEqual(result.queries, want) {
+		t.Errorf("SmartCount() queries = %v, want %v", result.queries, want)
+	}
+}
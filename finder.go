@@ -0,0 +1,98 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+//ErrNotFound is returned by First/Last/Take/FindByPK if no row matches,check it by errors.Is(err, sqlx.ErrNotFound)
+var ErrNotFound = errors.New("record not found")
+
+//First finds the first row ordered by the primary key (the field with `pri` tag) into the struct pointer
+//eg: err := db.Table("app").Where("type=?", "normal").First(&app)
+func (p *PgTable) First(dest interface{}) error {
+	return p.findByOrder("first", dest, Asc)
+}
+
+//Last finds the last row ordered by the primary key into the struct pointer
+func (p *PgTable) Last(dest interface{}) error {
+	return p.findByOrder("last", dest, Desc)
+}
+
+//Take finds a row into the struct pointer without ordering
+func (p *PgTable) Take(dest interface{}) error {
+	if err := checkStruct(dest); err != nil {
+		p.clear()
+		return fmt.Errorf("take:%w", err)
+	}
+	return p.take("take", dest)
+}
+
+//FindByPK finds the row whose primary key is id into the struct pointer
+//eg: err := db.Table("app").FindByPK(&app, 1); if errors.Is(err, sqlx.ErrNotFound) {...}
+func (p *PgTable) FindByPK(dest interface{}, id interface{}) error {
+	if err := checkStruct(dest); err != nil {
+		p.clear()
+		return fmt.Errorf("find by pk:%w", err)
+	}
+	_, column, err := primaryKey(reflect.TypeOf(dest).Elem())
+	if err != nil {
+		p.clear()
+		return fmt.Errorf("find by pk:%w", err)
+	}
+	p.where = andWhere(p.where, &storage{bucket: column + " = ?", argc: []interface{}{id}})
+	return p.take("find by pk", dest)
+}
+
+func (p *PgTable) findByOrder(label string, dest interface{}, order SortOrder) error {
+	if err := checkStruct(dest); err != nil {
+		p.clear()
+		return fmt.Errorf("%s:%w", label, err)
+	}
+	_, column, err := primaryKey(reflect.TypeOf(dest).Elem())
+	if err != nil {
+		p.clear()
+		return fmt.Errorf("%s:%w", label, err)
+	}
+	p.sort = []*storage{{storageType: storageTypeSort, bucket: column + " " + string(order)}}
+	return p.take(label, dest)
+}
+
+//take finds the first row of the query into dest,ErrNotFound is returned if there is no row
+func (p *PgTable) take(label string, dest interface{}) error {
+	p.limit = 1
+	rows, err := p.open(label)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s:%w", label, ErrNotFound)
+	}
+	if err = rows.Scan(dest); err != nil {
+		return fmt.Errorf("%s:%w", label, err)
+	}
+	return rows.Err()
+}
+
+func checkStruct(dest interface{}) error {
+	typ := reflect.TypeOf(dest)
+	if typ == nil || typ.Kind() != reflect.Ptr || reflect.ValueOf(dest).IsNil() || typ.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be a struct pointer")
+	}
+	return nil
+}
+
+//primaryKey finds the field with `pri` tag,it returns the index of the field and the quoted column
+func primaryKey(typ reflect.Type) (int, string, error) {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).Tag.Get("pri") != "" {
+			return i, quotePart(jsonName(typ.Field(i))), nil
+		}
+	}
+	return -1, "", fmt.Errorf("dest must have a primary key field with `pri` tag")
+}
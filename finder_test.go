@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeUser struct {
+	Id   int64  `json:"id" pri:"yes"`
+	Name string `json:"name"`
+}
+
+func TestPgTable_First(t *testing.T) {
+	tests := []struct {
+		name  string
+		find  func(pg *Pg, user *fakeUser) error
+		query string
+		args  []driver.Value
+	}{
+		{
+			name:  "first",
+			find:  func(pg *Pg, user *fakeUser) error { return pg.Table("user").Where("name=?", "a").First(user) },
+			query: `SELECT * FROM "user" WHERE name=$1 ORDER BY "id" ASC LIMIT 1`,
+			args:  []driver.Value{"a"},
+		},
+		{
+			name:  "last",
+			find:  func(pg *Pg, user *fakeUser) error { return pg.Table("user").Last(user) },
+			query: `SELECT * FROM "user" ORDER BY "id" DESC LIMIT 1`,
+			args:  []driver.Value{},
+		},
+		{
+			name:  "take",
+			find:  func(pg *Pg, user *fakeUser) error { return pg.Table("user").Take(user) },
+			query: `SELECT * FROM "user" LIMIT 1`,
+			args:  []driver.Value{},
+		},
+		{
+			name:  "find by pk",
+			find:  func(pg *Pg, user *fakeUser) error { return pg.Table("user").FindByPK(user, 2) },
+			query: `SELECT * FROM "user" WHERE "id" = $1 LIMIT 1`,
+			args:  []driver.Value{int64(2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, result := fakeDb(t, []string{"id", "name"}, []driver.Value{int64(2), "a"})
+			var user fakeUser
+			if err := tt.find(pg, &user); err != nil {
+				t.Fatal(err)
+			}
+			if user != (fakeUser{Id: 2, Name: "a"}) {
+				t.Errorf("%s() = %+v", tt.name, user)
+			}
+			if result.queries[0] != tt.query {
+				t.Errorf("%s() query = %v, want %v", tt.name, result.queries[0], tt.query)
+			}
+			if len(result.args[0]) != len(tt.args) || (len(tt.args) != 0 && !reflect.DeepEqual(result.args[0], tt.args)) {
+				t.Errorf("%s() args = %v, want %v", tt.name, result.args[0], tt.args)
+			}
+		})
+	}
+}
+
+func TestPgTable_FindByPK_whereOr(t *testing.T) {
+	pg, result := fakeDb(t, []string{"id", "name"}, []driver.Value{int64(5), "a"})
+	var user fakeUser
+	if err := pg.Table("user").Where("name=?", "a").WhereOr("name=?", "b").FindByPK(&user, 5); err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT * FROM "user" WHERE (name=$1 OR name=$2) AND "id" = $3 LIMIT 1`
+	if result.queries[0] != want {
+		t.Errorf("FindByPK() query = %v, want %v", result.queries[0], want)
+	}
+}
+
+func TestPgTable_First_notFound(t *testing.T) {
+	pg, _ := fakeDb(t, []string{"id", "name"})
+	var user fakeUser
+	if err := pg.Table("user").FindByPK(&user, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByPK() error = %v, want %v", err, ErrNotFound)
+	}
+	var app fakeApp
+	if err := pg.Table("app").First(&app); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("First() error = %v, want the primary key error", err)
+	}
+}
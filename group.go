@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+//Having adds a HAVING condition of the groups,it is joined with AND and supports the named placeholders like Where
+//eg: db.Table("order").Select("status", As(sqlx.CountOf("*"), "total")).Group("status").Having("COUNT(*) > ?", 10)
+func (p *PgTable) Having(having string, argc ...interface{}) Query {
+	p.having = append(p.having, &storage{
+		storageType: storageTypeWhereAnd,
+		bucket:      having,
+		argc:        argc,
+	})
+	return p.query
+}
+
+//HavingOr adds a HAVING condition joined with OR
+func (p *PgTable) HavingOr(having string, argc ...interface{}) Query {
+	p.having = append(p.having, &storage{
+		storageType: storageTypeWhereOr,
+		bucket:      having,
+		argc:        argc,
+	})
+	return p.query
+}
+
+//FindMap finds the rows (usually grouped) into a map pointer keyed by the first column,
+//if the map value is a scalar it is the second column,if it is a struct all the columns are mapped by the json tags like Find
+//eg:
+//	counts := map[string]int64{}
+//	err := db.Table("order").Select("status", sqlx.CountOf("*")).Group("status").FindMap(&counts)
+//	stats := map[string]StatusStats{}
+//	err = db.Table("order").Select("status", As(sqlx.SumOf("amount"), "amount")).Group("status").FindMap(&stats)
+func (p *PgTable) FindMap(dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Map {
+		p.clear()
+		return fmt.Errorf("find map:dest must be a map pointer")
+	}
+	mapType := destValue.Elem().Type()
+	structType := mapType.Elem()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	isStruct := structType.Kind() == reflect.Struct && structType != reflect.TypeOf(time.Time{}) &&
+		!reflect.PtrTo(structType).Implements(scannerType)
+	rows, err := p.open("find map")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	columns := len(rows.mapper.columns)
+	if columns < 2 || (!isStruct && columns != 2) {
+		return fmt.Errorf("find map:%d columns can't be found into %s", columns, mapType)
+	}
+	result := reflect.MakeMapWithSize(mapType, 0)
+	receivers := make([]interface{}, columns)
+	for rows.Next() {
+		key := reflect.New(mapType.Key()).Elem()
+		receivers[0] = scalarReceiver(key.Type())
+		value := reflect.New(mapType.Elem()).Elem()
+		if isStruct {
+			for i := 1; i < columns; i++ {
+				receivers[i] = new(interface{})
+			}
+			row := reflect.New(structType)
+			if err = rows.Scan(row.Interface()); err != nil {
+				return fmt.Errorf("find map:%w", err)
+			}
+			if value.Kind() == reflect.Ptr {
+				value.Set(row)
+			} else {
+				value.Set(row.Elem())
+			}
+		} else {
+			receivers[1] = scalarReceiver(value.Type())
+		}
+		if err = rows.rows.Scan(receivers...); err != nil {
+			return fmt.Errorf("find map:scan record error:%w", err)
+		}
+		if err = setScalar(key, receivers[0]); err != nil {
+			return fmt.Errorf("find map:%w", err)
+		}
+		if !isStruct {
+			if err = setScalar(value, receivers[1]); err != nil {
+				return fmt.Errorf("find map:%w", err)
+			}
+		}
+		result.SetMapIndex(key, value)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	destValue.Elem().Set(result)
+	return nil
+}
@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_Having(t *testing.T) {
+	pg := fakePg()
+	tests := []struct {
+		name   string
+		table  Table
+		op     opType
+		want   string
+		filler []interface{}
+	}{
+		{
+			name: "having",
+			table: pg.Table("order").Select("status", As(CountOf("*"), "total")).Where("amount > ?", 10).
+				Group("status").Having("COUNT(*) > ?", 2).HavingOr("SUM(amount) > ?", 100).(*PgQuery).table,
+			op:     opTypeQuery,
+			want:   `SELECT status,COUNT(*) AS "total" FROM "order" WHERE amount > $1 GROUP BY status HAVING COUNT(*) > $2 OR SUM(amount) > $3`,
+			filler: []interface{}{10, 2, 100},
+		},
+		{
+			name:   "count groups",
+			table:  pg.Table("order").Select("status").Group("status").Having("COUNT(*) > ?", 2).(*PgQuery).table,
+			op:     opTypeCount,
+			want:   `SELECT COUNT(*) FROM (SELECT status FROM "order" GROUP BY status HAVING COUNT(*) > $1) AS "t"`,
+			filler: []interface{}{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, filler, err := parse(tt.table, tt.op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("parseSQL() filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
+
+func TestPgTable_FindMap(t *testing.T) {
+	t.Run("scalar", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"status", "count"},
+			[]driver.Value{"paid", int64(3)},
+			[]driver.Value{"open", "2.000"},
+		)
+		counts := map[string]int64{}
+		if err := pg.Table("order").Select("status", CountOf("*")).Group("status").FindMap(&counts); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]int64{"paid": 3, "open": 2}
+		if !reflect.DeepEqual(counts, want) {
+			t.Errorf("FindMap() = %v, want %v", counts, want)
+		}
+	})
+	t.Run("struct", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"status", "total", "amount"},
+			[]driver.Value{int64(1), int64(3), 1.5},
+			[]driver.Value{int64(2), int64(1), nil},
+		)
+		type stats struct {
+			Status int64    `json:"status"`
+			Total  int64    `json:"total"`
+			Amount *float64 `json:"amount"`
+		}
+		var got map[int]*stats
+		err := pg.Table("order").Select("status", As(CountOf("*"), "total"), As(SumOf("amount"), "amount")).
+			Group("status").FindMap(&got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		amount := 1.5
+		want := map[int]*stats{1: {Status: 1, Total: 3, Amount: &amount}, 2: {Status: 2, Total: 1}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FindMap() = %+v, want %+v", got, want)
+		}
+	})
+	t.Run("columns", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"status"}, []driver.Value{"paid"})
+		counts := map[string]int64{}
+		if err := pg.Table("order").Select("status").FindMap(&counts); err == nil {
+			t.Error("FindMap() error = nil, want error")
+		}
+	})
+}
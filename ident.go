@@ -0,0 +1,132 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+//Raw is a sql fragment which is written into the statement verbatim
+//it is the only way to use expressions in Select/Group when the strict mode is on,so never build it from user input
+type Raw string
+
+//Ident is a column or table name like "id","app.id" or "billing.app.id",it is validated and quoted before use
+type Ident string
+
+//QuoteIdent validates the identifier and quotes each part of it
+//eg: billing.app.id => "billing"."app"."id", app.* => "app".*
+func QuoteIdent(name string) (string, error) {
+	parts, err := splitIdent(name)
+	if err != nil {
+		return "", err
+	}
+	var cond bytes.Buffer
+	for i, part := range parts {
+		if i != 0 {
+			cond.WriteString(".")
+		}
+		if part == "*" {
+			if i != len(parts)-1 {
+				return "", fmt.Errorf("ident:'*' must be the last part of '%s'", name)
+			}
+			cond.WriteString(part)
+			continue
+		}
+		cond.WriteString(quotePart(part))
+	}
+	return cond.String(), nil
+}
+
+//quotePart quotes one part of an identifier,the double quotes inside it are escaped
+func quotePart(part string) string {
+	return `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+}
+
+//splitIdent splits the identifier by ".",a part can be a plain name,a quoted name or "*"
+func splitIdent(name string) (parts []string, err error) {
+	if name == "" {
+		return nil, fmt.Errorf("ident:empty identifier")
+	}
+	runes := []rune(name)
+	for i := 0; i < len(runes); {
+		var part bytes.Buffer
+		switch {
+		case runes[i] == '"':
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '"' {
+					if i+1 < len(runes) && runes[i+1] == '"' {
+						part.WriteRune('"')
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				part.WriteRune(runes[i])
+				i++
+			}
+			if !closed || part.Len() == 0 {
+				return nil, fmt.Errorf("ident:invalid quoted identifier '%s'", name)
+			}
+		case runes[i] == '*':
+			part.WriteRune('*')
+			i++
+		default:
+			for i < len(runes) && runes[i] != '.' {
+				r := runes[i]
+				isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+				isDigit := (r >= '0' && r <= '9') || r == '$'
+				if !isLetter && !(isDigit && part.Len() != 0) {
+					return nil, fmt.Errorf("ident:invalid identifier '%s'", name)
+				}
+				part.WriteRune(r)
+				i++
+			}
+			if part.Len() == 0 {
+				return nil, fmt.Errorf("ident:invalid identifier '%s'", name)
+			}
+		}
+		parts = append(parts, part.String())
+		if i < len(runes) {
+			if runes[i] != '.' || i == len(runes)-1 {
+				return nil, fmt.Errorf("ident:invalid identifier '%s'", name)
+			}
+			i++
+		}
+	}
+	return parts, nil
+}
+
+//quoteColumns validates a column list like "id, app.name AS n, app.*" and quotes it
+func quoteColumns(columns string) (string, error) {
+	var list []string
+	for _, column := range strings.Split(columns, ",") {
+		words := strings.Fields(column)
+		var alias string
+		switch {
+		case len(words) == 1:
+		case len(words) == 2:
+			alias = words[1]
+		case len(words) == 3 && strings.EqualFold(words[1], "AS"):
+			alias = words[2]
+		default:
+			return "", fmt.Errorf("ident:invalid column '%s'", strings.TrimSpace(column))
+		}
+		quoted, err := QuoteIdent(words[0])
+		if err != nil {
+			return "", err
+		}
+		if alias != "" {
+			parts, err := splitIdent(alias)
+			if err != nil || len(parts) != 1 || parts[0] == "*" {
+				return "", fmt.Errorf("ident:invalid alias '%s'", alias)
+			}
+			quoted += " AS " + quotePart(parts[0])
+		}
+		list = append(list, quoted)
+	}
+	return strings.Join(list, ","), nil
+}
@@ -0,0 +1,149 @@
+package sql
+
+import (
+	"testing"
+)
+
+func TestQuoteIdent(t *testing.T) {
+	tests := []struct {
+		name    string
+		ident   string
+		want    string
+		wantErr bool
+	}{
+		{name: "column", ident: "id", want: `"id"`},
+		{name: "table column", ident: "app.id", want: `"app"."id"`},
+		{name: "schema table column", ident: "billing.app.id", want: `"billing"."app"."id"`},
+		{name: "star", ident: "app.*", want: `"app".*`},
+		{name: "quoted", ident: `"Weird ""Name"""`, want: `"Weird ""Name"""`},
+		{name: "empty", ident: "", wantErr: true},
+		{name: "star in the middle", ident: "*.id", wantErr: true},
+		{name: "trailing dot", ident: "app.", wantErr: true},
+		{name: "leading digit", ident: "1id", wantErr: true},
+		{name: "injection comment", ident: "id--", wantErr: true},
+		{name: "injection statement", ident: "id; DROP TABLE app", wantErr: true},
+		{name: "injection quote", ident: `id" OR "1"="1`, wantErr: true},
+		{name: "injection subquery", ident: "(SELECT password FROM users)", wantErr: true},
+		{name: "unclosed quote", ident: `"id`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := QuoteIdent(tt.ident)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QuoteIdent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("QuoteIdent() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPg_SetStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(pg SQL) Table
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "identifiers are quoted",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Select("id, app.name AS n").Sort("app.id", "desc")
+				return table
+			},
+			want: `SELECT "id","app"."name" AS "n" FROM "app" ORDER BY "app"."id" DESC`,
+		},
+		{
+			name: "raw and ident",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Select(Ident("type"), Raw("COUNT(*) AS total")).Group(Ident("type"))
+				return table
+			},
+			want: `SELECT "type",COUNT(*) AS total FROM "app" GROUP BY "type"`,
+		},
+		{
+			name: "select payload",
+			build: func(pg SQL) Table {
+				return pg.Table("app").Select("id, (SELECT password FROM users)")
+			},
+			wantErr: true,
+		},
+		{
+			name: "sort payload",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Sort("id; DROP TABLE app--", "asc")
+				return table
+			},
+			wantErr: true,
+		},
+		{
+			name: "group payload",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Group("type HAVING 1=1")
+				return table
+			},
+			wantErr: true,
+		},
+		{
+			name: "sort expression",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.SortExpr(Raw("position(? in name)"), Asc, NullsDefault, "a")
+				return table
+			},
+			want: `SELECT * FROM "app" ORDER BY position($1 in name) ASC`,
+		},
+		{
+			name: "sort expression string",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.SortExpr("position(? in name)", Asc, NullsDefault, "a")
+				return table
+			},
+			wantErr: true,
+		},
+		{
+			name: "group list",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Select("type, app.owner").Group("type, app.owner")
+				return table
+			},
+			want: `SELECT "type","app"."owner" FROM "app" GROUP BY "type","app"."owner"`,
+		},
+		{
+			name: "group alias",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Group("type AS t")
+				return table
+			},
+			wantErr: true,
+		},
+		{
+			name: "table payload",
+			build: func(pg SQL) Table {
+				return pg.Table(`app" WHERE 1=1 --`)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := fakePg()
+			pg.SetStrict(true)
+			got, _, err := parse(tt.build(pg), opTypeQuery)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package sql
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	//defaultCursorSecret signs the cursors when SetCursorSecret is not used,so the cursors are only valid in this process,
+	//it is generated by the first Keyset
+	defaultCursorSecret   []byte
+	defaultCursorSecretMu sync.Mutex
+)
+
+//KeysetPage is the result of Keyset,Next/Prev are the cursors of the next/previous page
+type KeysetPage struct {
+	Next    string `json:"next"`
+	Prev    string `json:"prev"`
+	HasNext bool   `json:"has_next"`
+	HasPrev bool   `json:"has_prev"`
+}
+
+//keysetCursor is the content of a cursor,the values of the sort columns and the paging direction
+type keysetCursor struct {
+	Values   []interface{} `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+//SetCursorSecret sets the secret used to sign the keyset cursors,it is needed if the cursors are shared by several processes
+func (p *Pg) SetCursorSecret(secret []byte) SQL {
+	p.cursorSecret = secret
+	return p
+}
+
+//Keyset finds a page after/before the cursor by the Sort columns instead of OFFSET,an empty cursor means the first page
+//the sort columns must be selected into dest and the last one should be unique,eg: Sort("created_date","desc").Sort("id","desc"),
+//the sort columns must not be NULL (use COALESCE in a subquery otherwise),an empty page has no cursors,restart from the first page
+//eg: page, err := db.Table("app").Sort("id", "asc").Keyset(cursor, 20, &apps)
+func (p *PgTable) Keyset(cursor string, size int64, dest interface{}) (*KeysetPage, error) {
+	defer p.clear()
+	if p.err != nil {
+		return nil, fmt.Errorf("keyset:%w", p.err)
+	}
+	if size < 1 {
+		return nil, fmt.Errorf("keyset:size must be greater than 0")
+	}
+	if len(p.sort) == 0 {
+		return nil, fmt.Errorf("keyset:please use 'Sort(...)' to set the keyset columns")
+	}
+	if reflect.TypeOf(dest).Kind() != reflect.Ptr || reflect.ValueOf(dest).Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("keyset:dest must be a slice pointer")
+	}
+	var columns []string
+	var orders []SortOrder
+	for _, row := range p.sort {
+		if len(row.argc) != 0 {
+			return nil, fmt.Errorf("keyset:sort expression with arguments is not supported")
+		}
+		columns = append(columns, sortExprOf(row))
+		orders = append(orders, sortOrderOf(row))
+	}
+	var key keysetCursor
+	if cursor != "" {
+		if err := p.decodeCursor(cursor, &key); err != nil {
+			return nil, err
+		}
+		if len(key.Values) != len(columns) {
+			return nil, fmt.Errorf("keyset:the cursor doesn't match the sort columns")
+		}
+		for i, value := range key.Values {
+			if value == nil {
+				return nil, fmt.Errorf("keyset:the value of sort column '%s' in the cursor is NULL", columns[i])
+			}
+		}
+	}
+
+	finder := p.clone()
+	finder.offset = 0
+	finder.limit = size + 1
+	if key.Backward {
+		finder.sort = nil
+		for _, row := range p.sort {
+			finder.sort = append(finder.sort, reverseSort(row))
+		}
+	}
+	if cursor != "" {
+		where, argc := keysetWhere(columns, orders, key.Values, key.Backward)
+		finder.where = andWhere(finder.where, &storage{bucket: where, argc: argc})
+	}
+	if err := finder.Find(dest); err != nil {
+		return nil, fmt.Errorf("keyset:%w", err)
+	}
+
+	rows := reflect.ValueOf(dest).Elem()
+	hasMore := int64(rows.Len()) > size
+	if hasMore {
+		rows.Set(rows.Slice(0, int(size)))
+	}
+	if key.Backward {
+		for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+			tmp := reflect.ValueOf(rows.Index(i).Interface())
+			rows.Index(i).Set(rows.Index(j))
+			rows.Index(j).Set(tmp)
+		}
+	}
+	page := &KeysetPage{HasNext: hasMore, HasPrev: cursor != ""}
+	if key.Backward {
+		page.HasNext, page.HasPrev = true, hasMore
+	}
+	if rows.Len() == 0 {
+		//there is no row to make the cursors of
+		page.HasNext, page.HasPrev = false, false
+		return page, nil
+	}
+	var err error
+	if page.HasNext {
+		if page.Next, err = p.encodeCursor(columns, rows.Index(rows.Len()-1), false); err != nil {
+			return nil, err
+		}
+	}
+	if page.HasPrev {
+		if page.Prev, err = p.encodeCursor(columns, rows.Index(0), true); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+//keysetWhere builds the condition of the rows after the cursor,eg: (a,b) > ($1,$2)
+//if the directions of the columns are different,it is expanded to: a > $1 OR (a = $2 AND b < $3)
+func keysetWhere(columns []string, orders []SortOrder, values []interface{}, backward bool) (string, []interface{}) {
+	operator := func(order SortOrder) string {
+		if (order == Desc) != backward {
+			return "<"
+		}
+		return ">"
+	}
+	sameOrder := true
+	for _, order := range orders {
+		sameOrder = sameOrder && order == orders[0]
+	}
+	if sameOrder {
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ","), operator(orders[0]), placeholders), values
+	}
+	var or []string
+	var argc []interface{}
+	for i := range columns {
+		var and []string
+		for j := 0; j < i; j++ {
+			and = append(and, columns[j]+" = ?")
+			argc = append(argc, values[j])
+		}
+		and = append(and, columns[i]+" "+operator(orders[i])+" ?")
+		argc = append(argc, values[i])
+		or = append(or, "("+strings.Join(and, " AND ")+")")
+	}
+	return "(" + strings.Join(or, " OR ") + ")", argc
+}
+
+//sortOrderOf returns the direction of the ORDER BY item,it is Asc if not set
+func sortOrderOf(row *storage) SortOrder {
+	expr := strings.TrimSuffix(strings.TrimSuffix(row.bucket, " "+string(NullsFirst)), " "+string(NullsLast))
+	if strings.HasSuffix(expr, " "+string(Desc)) {
+		return Desc
+	}
+	return Asc
+}
+
+//reverseSort reverses the direction and the nulls order of the ORDER BY item
+func reverseSort(row *storage) *storage {
+	order := Desc
+	if sortOrderOf(row) == Desc {
+		order = Asc
+	}
+	var bucket bytes.Buffer
+	bucket.WriteString(sortExprOf(row))
+	bucket.WriteString(" ")
+	bucket.WriteString(string(order))
+	if strings.HasSuffix(row.bucket, " "+string(NullsFirst)) {
+		bucket.WriteString(" " + string(NullsLast))
+	} else if strings.HasSuffix(row.bucket, " "+string(NullsLast)) {
+		bucket.WriteString(" " + string(NullsFirst))
+	}
+	return &storage{storageType: storageTypeSort, bucket: bucket.String()}
+}
+
+//encodeCursor signs the values of the sort columns of the row
+func (p *PgTable) encodeCursor(columns []string, row reflect.Value, backward bool) (string, error) {
+	for row.Kind() == reflect.Ptr {
+		row = row.Elem()
+	}
+	if row.Kind() != reflect.Struct {
+		return "", fmt.Errorf("keyset:dest must be a slice of struct")
+	}
+	key := keysetCursor{Backward: backward}
+	for _, column := range columns {
+		name := column
+		if parts, err := splitIdent(column); err == nil {
+			name = parts[len(parts)-1]
+		}
+		var found bool
+		for i := 0; i < row.NumField(); i++ {
+			if jsonName(row.Type().Field(i)) == name {
+				if field := row.Field(i); isNil(field) {
+					return "", fmt.Errorf("keyset:sort column '%s' is NULL", column)
+				}
+				key.Values = append(key.Values, row.Field(i).Interface())
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("keyset:sort column '%s' must be a field of dest", column)
+		}
+	}
+	payload, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("keyset:json marshal error:%w", err)
+	}
+	signature, err := p.signCursor(payload)
+	if err != nil {
+		return "", err
+	}
+	encoder := base64.RawURLEncoding
+	return encoder.EncodeToString(payload) + "." + encoder.EncodeToString(signature), nil
+}
+
+//decodeCursor verifies the signature of the cursor and decodes it
+func (p *PgTable) decodeCursor(cursor string, key *keysetCursor) error {
+	encoder := base64.RawURLEncoding
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return fmt.Errorf("keyset:invalid cursor")
+	}
+	payload, err := encoder.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("keyset:invalid cursor")
+	}
+	signature, err := encoder.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("keyset:invalid cursor signature")
+	}
+	expected, err := p.signCursor(payload)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(signature, expected) {
+		return fmt.Errorf("keyset:invalid cursor signature")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err = decoder.Decode(key); err != nil {
+		return fmt.Errorf("keyset:invalid cursor:%w", err)
+	}
+	return nil
+}
+
+func (p *PgTable) signCursor(payload []byte) ([]byte, error) {
+	secret := p.cursorSecret
+	if len(secret) == 0 {
+		var err error
+		if secret, err = defaultSecret(); err != nil {
+			return nil, err
+		}
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil), nil
+}
+
+//defaultSecret generates defaultCursorSecret on the first use
+func defaultSecret() ([]byte, error) {
+	defaultCursorSecretMu.Lock()
+	defer defaultCursorSecretMu.Unlock()
+	if defaultCursorSecret == nil {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("keyset:generate cursor secret error:%w", err)
+		}
+		defaultCursorSecret = secret
+	}
+	return defaultCursorSecret, nil
+}
+
+//isNil reports whether the field is a nil pointer/interface/map/slice,it is NULL in the database
+func isNil(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return field.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_keysetWhere(t *testing.T) {
+	tests := []struct {
+		name     string
+		orders   []SortOrder
+		backward bool
+		want     string
+		argc     []interface{}
+	}{
+		{
+			name:   "ascending",
+			orders: []SortOrder{Asc, Asc},
+			want:   "(created_date,id) > (?,?)",
+			argc:   []interface{}{"2022-01-01", 5},
+		},
+		{
+			name:     "descending backward",
+			orders:   []SortOrder{Desc, Desc},
+			backward: true,
+			want:     "(created_date,id) > (?,?)",
+			argc:     []interface{}{"2022-01-01", 5},
+		},
+		{
+			name:   "mixed",
+			orders: []SortOrder{Desc, Asc},
+			want:   "((created_date < ?) OR (created_date = ? AND id > ?))",
+			argc:   []interface{}{"2022-01-01", "2022-01-01", 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, argc := keysetWhere([]string{"created_date", "id"}, tt.orders, []interface{}{"2022-01-01", 5}, tt.backward)
+			if got != tt.want {
+				t.Errorf("keysetWhere() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(argc, tt.argc) {
+				t.Errorf("keysetWhere() argc = %v, want %v", argc, tt.argc)
+			}
+		})
+	}
+}
+
+func Test_reverseSort(t *testing.T) {
+	tests := []struct {
+		bucket string
+		want   string
+	}{
+		{bucket: "id", want: "id DESC"},
+		{bucket: "id ASC", want: "id DESC"},
+		{bucket: "created_date DESC NULLS LAST", want: "created_date ASC NULLS FIRST"},
+	}
+	for _, tt := range tests {
+		if got := reverseSort(&storage{bucket: tt.bucket}).bucket; got != tt.want {
+			t.Errorf("reverseSort(%s) = %s, want %s", tt.bucket, got, tt.want)
+		}
+	}
+}
+
+func TestPgTable_encodeCursor(t *testing.T) {
+	type row struct {
+		Id        int64     `json:"id"`
+		CreatedAt time.Time `json:"created_date"`
+	}
+	table := fakePg().Table("app").(*PgTable)
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor, err := table.encodeCursor([]string{"created_date", `"app"."id"`}, reflect.ValueOf(row{Id: 9, CreatedAt: created}), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key keysetCursor
+	if err = table.decodeCursor(cursor, &key); err != nil {
+		t.Fatal(err)
+	}
+	if !key.Backward || len(key.Values) != 2 || key.Values[0] != created.Format(time.RFC3339Nano) || key.Values[1].(interface{ String() string }).String() != "9" {
+		t.Errorf("decodeCursor() = %+v", key)
+	}
+	tampered := "f" + cursor[1:]
+	if cursor[0] == 'f' {
+		tampered = "e" + cursor[1:]
+	}
+	if err = table.decodeCursor(tampered, &key); err == nil {
+		t.Error("decodeCursor() accepted a tampered cursor")
+	}
+	other := fakePg()
+	other.SetCursorSecret([]byte("another secret"))
+	if err = other.Table("app").(*PgTable).decodeCursor(cursor, &key); err == nil {
+		t.Error("decodeCursor() accepted a cursor signed by another secret")
+	}
+}
+
+func TestPgTable_Keyset(t *testing.T) {
+	db, isFakeConn := conn()
+	if isFakeConn {
+		return
+	}
+	var first []TestTable
+	page, err := db.Table("app").Sort("id", "asc").Keyset("", 5, &first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !page.HasNext {
+		return
+	}
+	var second []TestTable
+	if page, err = db.Table("app").Sort("id", "asc").Keyset(page.Next, 5, &second); err != nil {
+		t.Fatal(err)
+	}
+	var back []TestTable
+	if _, err = db.Table("app").Sort("id", "asc").Keyset(page.Prev, 5, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, first) {
+		t.Errorf("Keyset() backward = %v, want %v", back, first)
+	}
+}
+
+func TestPgTable_Keyset_fake(t *testing.T) {
+	type row struct {
+		Id   int64   `json:"id"`
+		Name *string `json:"name"`
+	}
+	t.Run("where or", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(6)})
+		cursor, err := pg.Table("app").(*PgTable).encodeCursor([]string{"id"}, reflect.ValueOf(row{Id: 5}), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rows []row
+		if _, err = pg.Table("app").Where("type=?", "a").WhereOr("type=?", "b").Sort("id", "asc").Keyset(cursor, 2, &rows); err != nil {
+			t.Fatal(err)
+		}
+		want := `SELECT * FROM "app" WHERE (type=$1 OR type=$2) AND (id) > ($3) ORDER BY id ASC LIMIT 3`
+		if result.queries[0] != want {
+			t.Errorf("Keyset() query = %v, want %v", result.queries[0], want)
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"id", "name"}, []driver.Value{int64(1), nil}, []driver.Value{int64(2), nil})
+		var rows []row
+		if _, err := pg.Table("app").Sort("name", "asc").Keyset("", 1, &rows); err == nil {
+			t.Error("Keyset() error = nil, want the NULL sort column error")
+		}
+	})
+	t.Run("empty backward", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"id"})
+		cursor, err := pg.Table("app").(*PgTable).encodeCursor([]string{"id"}, reflect.ValueOf(row{Id: 1}), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rows []row
+		page, err := pg.Table("app").Sort("id", "asc").Keyset(cursor, 2, &rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.HasNext || page.HasPrev || page.Next != "" || page.Prev != "" {
+			t.Errorf("Keyset() = %+v, want an empty page without cursors", page)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//lock is the row-level locking clause of a SELECT
+type lock struct {
+	strength string
+	of       []string
+	wait     string
+}
+
+//ForUpdate locks the selected rows,of limits the locking to the given tables,
+//it can't be used with Union/Intersect/Except,Distinct/DistinctOn,Group,Window or the window/aggregate functions (so are the other locks)
+//eg: tx.Table("job").Where("status=?", "new").Limit(10).ForUpdate().SkipLocked().Find(&jobs)
+func (p *PgTable) ForUpdate(of ...string) Query {
+	return p.setLock("FOR UPDATE", of)
+}
+
+//ForNoKeyUpdate is like ForUpdate but doesn't block the inserts which reference the rows by foreign key
+func (p *PgTable) ForNoKeyUpdate(of ...string) Query {
+	return p.setLock("FOR NO KEY UPDATE", of)
+}
+
+//ForShare locks the selected rows in share mode
+func (p *PgTable) ForShare(of ...string) Query {
+	return p.setLock("FOR SHARE", of)
+}
+
+//ForKeyShare is like ForShare but only blocks the deletes and the updates of the keys
+func (p *PgTable) ForKeyShare(of ...string) Query {
+	return p.setLock("FOR KEY SHARE", of)
+}
+
+//SkipLocked skips the rows which are locked by the others,it must be used after ForUpdate/ForShare/...
+func (p *PgTable) SkipLocked() Query {
+	return p.setWait("SKIP LOCKED")
+}
+
+//NoWait returns an error instead of waiting for the locked rows,it must be used after ForUpdate/ForShare/...
+func (p *PgTable) NoWait() Query {
+	return p.setWait("NOWAIT")
+}
+
+func (p *PgTable) setLock(strength string, of []string) Query {
+	var tables []string
+	for _, table := range of {
+		quoted, err := QuoteIdent(table)
+		if err != nil {
+			p.setErr(fmt.Errorf("lock:%w", err))
+			return p.query
+		}
+		tables = append(tables, quoted)
+	}
+	p.lock = &lock{strength: strength, of: tables}
+	return p.query
+}
+
+func (p *PgTable) setWait(wait string) Query {
+	if p.lock == nil {
+		p.setErr(fmt.Errorf("lock:%s must be used after ForUpdate/ForNoKeyUpdate/ForShare/ForKeyShare", wait))
+		return p.query
+	}
+	p.lock.wait = wait
+	return p.query
+}
+
+func (p *PgTable) parseLock() string {
+	if p.meta.lock == nil {
+		return ""
+	}
+	var cond bytes.Buffer
+	cond.WriteString(" ")
+	cond.WriteString(p.lock.strength)
+	for i, table := range p.lock.of {
+		if i == 0 {
+			cond.WriteString(" OF ")
+		} else {
+			cond.WriteString(",")
+		}
+		cond.WriteString(table)
+	}
+	if p.lock.wait != "" {
+		cond.WriteString(" ")
+		cond.WriteString(p.lock.wait)
+	}
+	return cond.String()
+}
@@ -0,0 +1,103 @@
+package sql
+
+import (
+	"testing"
+)
+
+func TestPgTable_ForUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(pg SQL) Query
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "for update skip locked",
+			build: func(pg SQL) Query {
+				return pg.Table("job").Where("status=?", "new").Sort("id", "asc").Limit(10).ForUpdate().SkipLocked()
+			},
+			want: `SELECT * FROM "job" WHERE status=$1 ORDER BY id ASC LIMIT 10 FOR UPDATE SKIP LOCKED`,
+		},
+		{
+			name: "for no key update of tables nowait",
+			build: func(pg SQL) Query {
+				return pg.Table("job").ForNoKeyUpdate("job", "public.worker").NoWait()
+			},
+			want: `SELECT * FROM "job" FOR NO KEY UPDATE OF "job","public"."worker" NOWAIT`,
+		},
+		{
+			name: "for share",
+			build: func(pg SQL) Query {
+				return pg.Table("job").ForShare()
+			},
+			want: `SELECT * FROM "job" FOR SHARE`,
+		},
+		{
+			name: "for key share",
+			build: func(pg SQL) Query {
+				return pg.Table("job").ForKeyShare()
+			},
+			want: `SELECT * FROM "job" FOR KEY SHARE`,
+		},
+		{
+			name: "skip locked without lock",
+			build: func(pg SQL) Query {
+				return pg.Table("job").SkipLocked()
+			},
+			wantErr: true,
+		},
+		{
+			name: "union",
+			build: func(pg SQL) Query {
+				return pg.Table("job").Union(pg.Table("job_archive")).ForUpdate()
+			},
+			wantErr: true,
+		},
+		{
+			name: "distinct",
+			build: func(pg SQL) Query {
+				return pg.Table("job").Distinct().ForShare()
+			},
+			wantErr: true,
+		},
+		{
+			name: "group",
+			build: func(pg SQL) Query {
+				return pg.Table("job").Select("status").Group("status").ForUpdate()
+			},
+			wantErr: true,
+		},
+		{
+			name: "window function",
+			build: func(pg SQL) Query {
+				return pg.Table("job").Select("id", As(RowNumber().Over(NewWindow()), "rn")).ForUpdate()
+			},
+			wantErr: true,
+		},
+		{
+			name: "aggregate function",
+			build: func(pg SQL) Query {
+				return pg.Table("job").Select(CountOf("*")).ForShare()
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid table",
+			build: func(pg SQL) Query {
+				return pg.Table("job").ForUpdate("job; DROP TABLE job")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parse(tt.build(fakePg()).(*PgQuery).table, opTypeQuery)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
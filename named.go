@@ -0,0 +1,215 @@
+package sql
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+//namedArgs converts the only argument into the named arguments if it is a map with string keys or a struct,
+//the fields of the struct are named by the json tags
+func namedArgs(argc []interface{}) (map[string]interface{}, bool) {
+	if len(argc) != 1 || argc[0] == nil {
+		return nil, false
+	}
+	if _, ok := subqueryOf(argc[0]); ok {
+		return nil, false
+	}
+	value := reflect.ValueOf(argc[0])
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, false
+		}
+		value = value.Elem()
+	}
+	args := map[string]interface{}{}
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			args[iter.Key().String()] = iter.Value().Interface()
+		}
+	case reflect.Struct:
+		if !isArgStruct(value.Type()) {
+			return nil, false
+		}
+		structArgs(value, args)
+	default:
+		return nil, false
+	}
+	return args, true
+}
+
+func isArgStruct(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ != reflect.TypeOf(time.Time{}) && !reflect.PtrTo(typ).Implements(valuerType)
+}
+
+//structArgs puts the fields of the struct into args,the anonymous struct fields without json names are flattened
+//like encoding/json,the outer fields win over the embedded ones
+func structArgs(value reflect.Value, args map[string]interface{}) {
+	var embedded []reflect.Value
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == field.Name {
+			inner := value.Field(i)
+			if inner.Kind() == reflect.Ptr {
+				if inner.IsNil() {
+					continue
+				}
+				inner = inner.Elem()
+			}
+			if isArgStruct(inner.Type()) {
+				embedded = append(embedded, inner)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		args[name] = value.Field(i).Interface()
+	}
+	for _, inner := range embedded {
+		innerArgs := map[string]interface{}{}
+		structArgs(inner, innerArgs)
+		for name, arg := range innerArgs {
+			if _, ok := args[name]; !ok {
+				args[name] = arg
+			}
+		}
+	}
+}
+
+//bindNamed replaces the named placeholders (:name or @name) in expr with "$n",the same name reuses the same "$n",
+//the string literals (including E'...' and $$...$$),the quoted identifiers,the comments and the casts (::type) are skipped,
+//it returns false if there is no named placeholder,a "?" can't be used together with the named placeholders
+func (p *PgTable) bindNamed(expr string, args map[string]interface{}) (string, bool) {
+	var cond bytes.Buffer
+	placeholders := map[string]string{}
+	var found, positional bool
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\'' || c == '"' || c == '$' || (c == '-' && strings.HasPrefix(expr[i:], "--")) || (c == '/' && strings.HasPrefix(expr[i:], "/*")):
+			end := skipLiteral(expr, i)
+			if end == i {
+				break
+			}
+			cond.WriteString(expr[i:end])
+			i = end - 1
+			continue
+		case c == '?':
+			positional = true
+		case c == ':' && i+1 < len(expr) && expr[i+1] == ':':
+			cond.WriteString("::")
+			i++
+			continue
+		case (c == ':' || c == '@') && i+1 < len(expr) && isNameStart(expr[i+1]) && (i == 0 || !isNamePart(expr[i-1])):
+			j := i + 1
+			for j < len(expr) && isNamePart(expr[j]) {
+				j++
+			}
+			name := expr[i+1 : j]
+			i = j - 1
+			found = true
+			value, ok := args[name]
+			if !ok {
+				p.setErr(fmt.Errorf("bind:missing named argument '%s' for '%s'", name, expr))
+				continue
+			}
+			if sub, ok := subqueryOf(value); ok {
+				cond.WriteString("(")
+				cond.WriteString(p.parseSubquery(sub))
+				cond.WriteString(")")
+				continue
+			}
+			placeholder, ok := placeholders[name]
+			if !ok {
+				p.storageCursor++
+				placeholder = "$" + strconv.Itoa(p.storageCursor)
+				placeholders[name] = placeholder
+				p.filler = append(p.filler, value)
+			}
+			cond.WriteString(placeholder)
+			continue
+		}
+		cond.WriteByte(c)
+	}
+	if found && positional {
+		p.setErr(fmt.Errorf("bind:the named and the positional placeholders can't be mixed in '%s'", expr))
+	}
+	return cond.String(), found
+}
+
+//skipLiteral returns the end of the string literal,the quoted identifier,the dollar-quoted body or the comment
+//which starts at i,it returns i if there is none
+func skipLiteral(expr string, i int) int {
+	switch c := expr[i]; {
+	case c == '\'':
+		escape := i > 0 && (expr[i-1] == 'E' || expr[i-1] == 'e') && (i == 1 || !isNamePart(expr[i-2]))
+		for j := i + 1; j < len(expr); j++ {
+			if escape && expr[j] == '\\' {
+				j++
+				continue
+			}
+			if expr[j] == '\'' {
+				return j + 1
+			}
+		}
+		return len(expr)
+	case c == '"':
+		if end := strings.IndexByte(expr[i+1:], '"'); end != -1 {
+			return i + end + 2
+		}
+		return len(expr)
+	case c == '$':
+		if i > 0 && isNamePart(expr[i-1]) {
+			return i
+		}
+		j := i + 1
+		if j < len(expr) && isNameStart(expr[j]) {
+			for j < len(expr) && isNamePart(expr[j]) {
+				j++
+			}
+		}
+		if j >= len(expr) || expr[j] != '$' {
+			return i
+		}
+		tag := expr[i : j+1]
+		if end := strings.Index(expr[j+1:], tag); end != -1 {
+			return j + 1 + end + len(tag)
+		}
+		return len(expr)
+	case strings.HasPrefix(expr[i:], "--"):
+		if end := strings.IndexByte(expr[i:], '\n'); end != -1 {
+			return i + end
+		}
+		return len(expr)
+	case strings.HasPrefix(expr[i:], "/*"):
+		if end := strings.Index(expr[i+2:], "*/"); end != -1 {
+			return i + end + 4
+		}
+		return len(expr)
+	}
+	return i
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
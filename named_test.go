@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+type namedFilter struct {
+	Status int    `json:"status"`
+	User   string `json:"user"`
+	Secret string `json:"-"`
+}
+
+type embeddedFilter struct {
+	namedFilter
+	Status int    `json:"status"`
+	Owner  string `json:"owner"`
+}
+
+func TestPgTable_bindNamed(t *testing.T) {
+	pg := fakePg()
+	tests := []struct {
+		name   string
+		table  Table
+		want   string
+		filler []interface{}
+		err    bool
+	}{
+		{
+			name: "map",
+			table: pg.Table("task").Where("status = :status AND (owner = @user OR creator = @user)",
+				map[string]interface{}{"status": 1, "user": "bob"}).Where("id > ?", 10),
+			want:   `SELECT * FROM "task" WHERE status = $1 AND (owner = $2 OR creator = $2) AND id > $3`,
+			filler: []interface{}{1, "bob", 10},
+		},
+		{
+			name: "struct",
+			table: pg.Table("task").Select("owner").Where("status = :status", &namedFilter{Status: 2}).
+				Group("owner").Having("COUNT(*) > :status OR owner = :user", namedFilter{Status: 3, User: "bob"}).(*PgQuery).table,
+			want:   `SELECT owner FROM "task" WHERE status = $1 GROUP BY owner HAVING COUNT(*) > $2 OR owner = $3`,
+			filler: []interface{}{2, 3, "bob"},
+		},
+		{
+			name:   "literals and casts",
+			table:  pg.Table("task").Where(`created::date = :day::date AND note <> ':day' AND "a:day" = @day`, map[string]interface{}{"day": "2022-01-02"}),
+			want:   `SELECT * FROM "task" WHERE created::date = $1::date AND note <> ':day' AND "a:day" = $1`,
+			filler: []interface{}{"2022-01-02"},
+		},
+		{
+			name: "escape strings,dollar quotes and comments",
+			table: pg.Table("task").Where("note <> E'it\\'s :day' AND body <> $$ :day $$ AND tag <> $t$ :day $t$ /* :day */ AND day = :day -- :day\n",
+				map[string]interface{}{"day": "2022-01-02"}),
+			want:   "SELECT * FROM \"task\" WHERE note <> E'it\\'s :day' AND body <> $$ :day $$ AND tag <> $t$ :day $t$ /* :day */ AND day = $1 -- :day\n",
+			filler: []interface{}{"2022-01-02"},
+		},
+		{
+			name:   "embedded struct",
+			table:  pg.Table("task").Where("status = :status AND owner = :owner AND creator = :user", embeddedFilter{namedFilter: namedFilter{Status: 1, User: "bob"}, Status: 2, Owner: "alice"}),
+			want:   `SELECT * FROM "task" WHERE status = $1 AND owner = $2 AND creator = $3`,
+			filler: []interface{}{2, "alice", "bob"},
+		},
+		{
+			name:   "subquery",
+			table:  pg.Table("task").Where("owner IN :owners AND status = :status", map[string]interface{}{"owners": pg.Table("user").Select("name").Where("age > ?", 18), "status": 1}),
+			want:   `SELECT * FROM "task" WHERE owner IN (SELECT name FROM "user" WHERE age > $1) AND status = $2`,
+			filler: []interface{}{18, 1},
+		},
+		{
+			name:  "missing",
+			table: pg.Table("task").Where("status = :status", map[string]interface{}{"state": 1}),
+			err:   true,
+		},
+		{
+			name:  "mixed",
+			table: pg.Table("task").Where("status = :status AND id = ?", map[string]interface{}{"status": 1}),
+			err:   true,
+		},
+		{
+			name:  "secret",
+			table: pg.Table("task").Where("secret = :Secret", namedFilter{Secret: "x"}),
+			err:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, filler, err := parse(tt.table, opTypeQuery)
+			if (err != nil) != tt.err {
+				t.Fatalf("parseSQL() error = %v, want error %v", err, tt.err)
+			}
+			if tt.err {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("parseSQL() filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
+
+func TestPg_Raw_named(t *testing.T) {
+	pg, result := fakeDb(t, []string{"id"})
+	var ids []int64
+	err := pg.Raw("SELECT id FROM app WHERE owner = :owner OR creator = :owner", map[string]interface{}{"owner": "bob"}).Pluck("id", &ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT id FROM (SELECT id FROM app WHERE owner = $1 OR creator = $1` + "\n" + `) AS "t"`; result.queries[0] != want {
+		t.Errorf("Pluck() query = %v, want %v", result.queries[0], want)
+	}
+	if args := []driver.Value{"bob"}; !reflect.DeepEqual(result.args[0], args) {
+		t.Errorf("Pluck() args = %v, want %v", result.args[0], args)
+	}
+}
@@ -0,0 +1,91 @@
+package sql
+
+import (
+	"fmt"
+	"sync"
+)
+
+//Page is the metadata of a page returned by Paginate
+type Page struct {
+	Page    int64 `json:"page"`
+	Size    int64 `json:"size"`
+	Total   int64 `json:"total"`
+	Pages   int64 `json:"pages"`
+	HasNext bool  `json:"has_next"`
+	HasPrev bool  `json:"has_prev"`
+}
+
+//Paginate finds the page (starts from 1) into dest and counts the total rows with the same conditions
+//eg: page, err := db.Table("app").Where("type=?", "normal").Sort("id", "desc").Paginate(2, 20, &apps)
+func (p *PgTable) Paginate(page, size int64, dest interface{}) (*Page, error) {
+	return p.paginate(page, size, dest, false)
+}
+
+//PaginateConcurrently is like Paginate but runs the count and the page query at the same time,
+//inside a transaction they are still run one by one
+func (p *PgTable) PaginateConcurrently(page, size int64, dest interface{}) (*Page, error) {
+	return p.paginate(page, size, dest, true)
+}
+
+func (p *PgTable) paginate(page, size int64, dest interface{}, concurrent bool) (*Page, error) {
+	defer p.clear()
+	if p.err != nil {
+		return nil, fmt.Errorf("paginate:%w", p.err)
+	}
+	if size < 1 {
+		return nil, fmt.Errorf("paginate:size must be greater than 0")
+	}
+	if page < 1 {
+		page = 1
+	}
+	counter, finder := p.clone(), p.clone()
+	counter.sort, counter.limit, counter.offset, counter.lock = nil, 0, 0, nil
+	if !counter.distinct && len(counter.distinctOn) == 0 && len(counter.group) == 0 {
+		counter.fields = nil
+	}
+	finder.offset = (page - 1) * size
+	finder.limit = size
+
+	var total int64
+	var countErr, findErr error
+	if concurrent && p.tx == nil {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			countErr = counter.Count(&total)
+		}()
+		go func() {
+			defer wg.Done()
+			findErr = finder.Find(dest)
+		}()
+		wg.Wait()
+	} else {
+		if countErr = counter.Count(&total); countErr == nil {
+			findErr = finder.Find(dest)
+		}
+	}
+	if countErr != nil {
+		return nil, fmt.Errorf("paginate:%w", countErr)
+	}
+	if findErr != nil {
+		return nil, fmt.Errorf("paginate:%w", findErr)
+	}
+	pages := (total + size - 1) / size
+	return &Page{
+		Page:    page,
+		Size:    size,
+		Total:   total,
+		Pages:   pages,
+		HasNext: page < pages,
+		HasPrev: page > 1,
+	}, nil
+}
+
+//clone copies the conditions into a new builder,so the builder can be run more than once
+func (p *PgTable) clone() *PgTable {
+	m := *p.meta
+	m.filler = nil
+	m.storageCursor = 0
+	return p.Pg.builder(&m)
+}
@@ -0,0 +1,107 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestPgTable_Paginate(t *testing.T) {
+	db, isFakeConn := conn()
+	if isFakeConn {
+		return
+	}
+	var apps []TestTable
+	page, err := db.Table("app").Where("type=?", "normal").Sort("id", "desc").Paginate(2, 5, &apps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Page != 2 || page.Size != 5 || !page.HasPrev {
+		t.Errorf("Paginate() = %+v", page)
+	}
+	if int64(len(apps)) > page.Size {
+		t.Errorf("Paginate() found %d rows, want at most %d", len(apps), page.Size)
+	}
+	var concurrentApps []TestTable
+	concurrentPage, err := db.Table("app").Where("type=?", "normal").Sort("id", "desc").PaginateConcurrently(2, 5, &concurrentApps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *concurrentPage != *page {
+		t.Errorf("PaginateConcurrently() = %+v, want %+v", concurrentPage, page)
+	}
+}
+
+func TestPgTable_clone(t *testing.T) {
+	table := fakePg().Table("app").Select("type").Where("type=?", "normal").Group("type").(*PgQuery).table
+	counter := table.clone()
+	counter.sort, counter.limit = nil, 0
+	got, filler, err := parse(counter, opTypeCount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT COUNT(*) FROM (SELECT type FROM "app" WHERE type=$1 GROUP BY type) AS "t"`
+	if got != want {
+		t.Errorf("parseSQL() = %s, want %s", got, want)
+	}
+	if len(filler) != 1 || len(table.filler) != 0 {
+		t.Errorf("filler = %v, the filler of the original builder = %v", filler, table.filler)
+	}
+}
+
+func TestPgTable_Paginate_fake(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int64
+		concurrent bool
+		want       Page
+		offset     string
+	}{
+		{name: "first", page: 0, want: Page{Page: 1, Size: 5, Total: 12, Pages: 3, HasNext: true}},
+		{name: "middle", page: 2, want: Page{Page: 2, Size: 5, Total: 12, Pages: 3, HasNext: true, HasPrev: true}, offset: " OFFSET 5"},
+		{name: "last", page: 3, concurrent: true, want: Page{Page: 3, Size: 5, Total: 12, Pages: 3, HasPrev: true}, offset: " OFFSET 10"},
+		{name: "beyond the last", page: 4, want: Page{Page: 4, Size: 5, Total: 12, Pages: 3, HasPrev: true}, offset: " OFFSET 15"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(12)})
+			var apps []struct {
+				Id int64 `json:"id"`
+			}
+			table := pg.Table("app").Where("type=?", "normal").Sort("id", "desc")
+			paginate := table.Paginate
+			if tt.concurrent {
+				paginate = table.PaginateConcurrently
+			}
+			page, err := paginate(tt.page, 5, &apps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *page != tt.want {
+				t.Errorf("Paginate() = %+v, want %+v", *page, tt.want)
+			}
+			queries := map[string]bool{}
+			for _, query := range result.queries {
+				queries[query] = true
+			}
+			count := `SELECT COUNT(*) FROM "app" WHERE type=$1`
+			find := `SELECT * FROM "app" WHERE type=$1 ORDER BY id DESC` + tt.offset + " LIMIT 5"
+			if len(result.queries) != 2 || !queries[count] || !queries[find] {
+				t.Errorf("Paginate() queries = %v, want %s and %s", result.queries, count, find)
+			}
+		})
+	}
+}
+
+func TestPgTable_Count_group(t *testing.T) {
+	pg, result := fakeDb(t, []string{"count"}, []driver.Value{int64(3)})
+	var count int64
+	if err := pg.Table("app").Select("type").Group("type").Count(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("Count() = %d, want 3", count)
+	}
+	if want := `SELECT COUNT(*) FROM (SELECT type FROM "app" GROUP BY type) AS "t"`; result.queries[0] != want {
+		t.Errorf("Count() query = %v, want %v", result.queries[0], want)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
-	"encoding/json"
 	"fmt"
 	_ "github.com/lib/pq"
 	"os"
@@ -15,26 +14,50 @@ import (
 )
 
 type Pg struct {
-	db    *sql.DB
+	db *sql.DB
+	//tx is set inside Transaction(...),the builders use it instead of db
+	tx    *sql.Tx
 	table *PgTable
 	query *PgQuery
 	dsn   bytes.Buffer
+	//strict mode only accepts identifiers or Raw(...) as table/column names
+	strict bool
+	//schema is used to qualify the table names without schema
+	schema string
+	//cursorSecret signs the keyset cursors
+	cursorSecret []byte
 	*meta
 }
 
 type meta struct {
 	dbName        string
 	tableName     string
-	fields        string
+	schema        string
+	fields        []*storage
+	from          *storage
 	where         []*storage
-	sort          bytes.Buffer
+	sort          []*storage
 	limit         int64
 	offset        int64
-	group         string
+	group         []*storage
+	having        []*storage
+	sample        *storage
+	raw           *storage
+	with          []*cte
+	cteNames      map[string]bool
+	compound      []*storage
+	lock          *lock
+	distinct      bool
+	distinctOn    []*storage
+	windows       []*namedWindow
+	funcColumns   bool
+	cursorBatch   int64
+	returning     []*storage
 	retryTimes    int
 	storageCursor int
 	filler        []interface{}
 	ctx           context.Context
+	err           error
 }
 
 type storage struct {
@@ -49,6 +72,25 @@ const (
 	storageTypeWhereAnd storageType = 1
 	storageTypeWhereOr  storageType = 2
 	storageTypeSaveData storageType = 3
+	storageTypeSort     storageType = 4
+	storageTypeCompound storageType = 5
+)
+
+//SortOrder is the direction of an ORDER BY item
+type SortOrder string
+
+const (
+	Asc  SortOrder = "ASC"
+	Desc SortOrder = "DESC"
+)
+
+//Nulls decides where NULL values are placed in an ORDER BY item,empty means the postgres default
+type Nulls string
+
+const (
+	NullsDefault Nulls = ""
+	NullsFirst   Nulls = "NULLS FIRST"
+	NullsLast    Nulls = "NULLS LAST"
 )
 
 type opType int
@@ -63,6 +105,10 @@ const (
 	opTypeSave    opType = 7
 	opTypeSaveInt opType = 8
 	opTypeSaveDec opType = 9
+	opTypeMax     opType = 10
+	opTypeMin     opType = 11
+	//opTypeCountDistinct is COUNT(DISTINCT field)
+	opTypeCountDistinct opType = 12
 )
 
 //NewPg is used to create postgres connection
@@ -121,11 +167,84 @@ func (p *Pg) Conn() *sql.DB {
 	return p.db
 }
 
+//SetStrict turns the strict mode on/off,in strict mode the strings passed to Table/Select/Sort/Group/SetInc/SetDec
+//must be identifiers like "app.id",expressions must be wrapped in Raw(...)
+func (p *Pg) SetStrict(strict bool) SQL {
+	p.strict = strict
+	return p
+}
+
+//SetSchema sets the default schema of the connection,eg: SetSchema("billing") then Table("invoices") => "billing"."invoices"
+//the table names with schema and the Schema(...) step are not affected
+func (p *Pg) SetSchema(schema string) SQL {
+	p.schema = schema
+	return p
+}
+
+//Table creates a new builder of the table,the builders don't share their conditions
+//so a builder can be used as the subquery of another one
 func (p *Pg) Table(tableName string) Table {
-	p.tableName = tableName
-	p.fields = "*"
-	p.ctx = context.Background()
-	return p.table
+	return p.builder(&meta{
+		tableName: tableName,
+		ctx:       context.Background(),
+	})
+}
+
+//From creates a new builder which selects from the subquery,eg: From(db.Table("app").Select("type"), "t")
+func (p *Pg) From(subquery interface{}, alias string) Table {
+	table := p.builder(&meta{ctx: context.Background()})
+	table.From(subquery, alias)
+	return table
+}
+
+func (p *Pg) builder(m *meta) *PgTable {
+	pg := &Pg{db: p.db, tx: p.tx, strict: p.strict, schema: p.schema, cursorSecret: p.cursorSecret, meta: m}
+	pg.table = &PgTable{Pg: pg}
+	pg.query = &PgQuery{Pg: pg}
+	return pg.table
+}
+
+//executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (p *Pg) executor() executor {
+	if p.tx != nil {
+		return p.tx
+	}
+	return p.db
+}
+
+//Transaction runs f in a transaction,the builders created by tx.Table(...) run inside it
+//the transaction is committed if f returns nil,otherwise it is rolled back
+func (p *Pg) Transaction(f func(tx SQL) error) (err error) {
+	if p.tx != nil {
+		return f(p)
+	}
+	tx, err := p.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("transaction:begin error:%w", err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+	if err = f(&Pg{db: p.db, tx: tx, strict: p.strict, schema: p.schema, cursorSecret: p.cursorSecret, meta: &meta{}}); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction:rollback error:%v:%w", rollbackErr, err)
+		}
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("transaction:commit error:%w", err)
+	}
+	return nil
 }
 
 func (p *Pg) clear() {
@@ -136,11 +255,56 @@ type PgTable struct {
 	*Pg
 }
 
-func (p *PgTable) Select(fields string) Table {
-	p.fields = fields
+//Schema sets the schema of the table for this query only
+func (p *PgTable) Schema(schema string) Table {
+	p.meta.schema = schema
+	return p
+}
+
+//Select sets the selected columns,each one can be a string (eg: "id,name"),an Ident,a Raw expression,
+//a subquery builder or As(column, alias)
+func (p *PgTable) Select(fields ...interface{}) Table {
+	p.fields = p.parseColumns(fields)
+	p.funcColumns = hasFuncColumn(fields)
 	return p.table
 }
 
+//hasFuncColumn reports whether there is a window function or an aggregate function in the columns
+func hasFuncColumn(columns []interface{}) bool {
+	for _, column := range columns {
+		switch c := column.(type) {
+		case *WindowFunc, *AggregateFunc:
+			return true
+		case *alias:
+			if hasFuncColumn([]interface{}{c.column}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//From replaces the table with a subquery,eg: FROM (SELECT ...) AS "t"
+func (p *PgTable) From(subquery interface{}, alias string) Table {
+	if _, ok := subqueryOf(subquery); !ok {
+		p.setErr(fmt.Errorf("from:subquery must be a Table/Query builder"))
+		return p
+	}
+	parts, err := splitIdent(alias)
+	if err != nil || len(parts) != 1 || parts[0] == "*" {
+		p.setErr(fmt.Errorf("from:invalid alias '%s'", alias))
+		return p
+	}
+	p.from = &storage{
+		bucket: "? AS " + quotePart(parts[0]),
+		argc:   []interface{}{subquery},
+	}
+	return p
+}
+
+//Where adds a condition joined with AND,the arguments are bound to "?" in order,or to the named placeholders
+//(:name or @name) if the only argument is a map[string]interface{} or a struct (named by the json tags)
+//eg: Where("status = :status AND (owner = :user OR creator = :user)", map[string]interface{}{"status": 1, "user": "bob"})
 func (p *PgTable) Where(where string, argc ...interface{}) Table {
 	p.where = append(p.where, &storage{
 		storageType: storageTypeWhereAnd,
@@ -159,12 +323,64 @@ func (p *PgTable) WhereOr(where string, argc ...interface{}) Table {
 	return p
 }
 
-func (p *PgTable) Sort(filed string, sortBy string) Query {
-	p.sort.Reset()
-	p.sort.WriteString(" ORDER BY ")
-	p.sort.WriteString(filed)
-	p.sort.WriteString(" ")
-	p.sort.WriteString(sortBy)
+//Sort appends an ORDER BY item,sortBy must be "asc" or "desc" (case insensitive) or empty,
+//filed is a column name (it is always validated),an Ident or a Raw expression
+func (p *PgTable) Sort(filed interface{}, sortBy string) Query {
+	return p.sortBy(p.parseSortColumn(filed), SortOrder(strings.ToUpper(strings.TrimSpace(sortBy))), NullsDefault, nil)
+}
+
+//SortNulls appends an ORDER BY item with NULLS FIRST/LAST,filed is the same as Sort
+func (p *PgTable) SortNulls(filed interface{}, order SortOrder, nulls Nulls) Query {
+	return p.sortBy(p.parseSortColumn(filed), order, nulls, nil)
+}
+
+//SortExpr appends an ORDER BY expression,the "?" in expr will be bound with argc,
+//expr can be a string or a Raw,it must be a Raw when the strict mode is on
+//eg: SortExpr("position(? in name)", sqlx.Asc, sqlx.NullsLast, "abc")
+func (p *PgTable) SortExpr(expr interface{}, order SortOrder, nulls Nulls, argc ...interface{}) Query {
+	switch e := expr.(type) {
+	case Raw:
+		return p.sortBy(string(e), order, nulls, argc)
+	case string:
+		if p.strict {
+			p.setErr(fmt.Errorf("sort:the expression must be a Raw in strict mode"))
+			return p.query
+		}
+		return p.sortBy(e, order, nulls, argc)
+	default:
+		p.setErr(fmt.Errorf("sort:unsupported expression type %T", expr))
+		return p.query
+	}
+}
+
+func (p *PgTable) sortBy(expr string, order SortOrder, nulls Nulls, argc []interface{}) Query {
+	switch order {
+	case Asc, Desc, "":
+	default:
+		p.setErr(fmt.Errorf("sort:invalid sort order '%s'", order))
+		return p.query
+	}
+	switch nulls {
+	case NullsDefault, NullsFirst, NullsLast:
+	default:
+		p.setErr(fmt.Errorf("sort:invalid nulls order '%s'", nulls))
+		return p.query
+	}
+	var bucket bytes.Buffer
+	bucket.WriteString(expr)
+	if order != "" {
+		bucket.WriteString(" ")
+		bucket.WriteString(string(order))
+	}
+	if nulls != NullsDefault {
+		bucket.WriteString(" ")
+		bucket.WriteString(string(nulls))
+	}
+	p.sort = append(p.sort, &storage{
+		storageType: storageTypeSort,
+		bucket:      bucket.String(),
+		argc:        argc,
+	})
 	return p.query
 }
 
@@ -178,120 +394,70 @@ func (p *PgTable) Limit(limit int64) Query {
 	return p.query
 }
 
-func (p *PgTable) Group(group string) Query {
-	p.group = group
+//Group sets the GROUP BY columns,each one can be a string (a column list like "type, app.owner",it is always validated),
+//an Ident or a Raw expression
+func (p *PgTable) Group(group ...interface{}) Query {
+	p.group = p.parseGroup(group)
 	return p.query
 }
 
+//Find finds the rows into dest,dest is a struct pointer (the first row) or a slice pointer,
+//it can be a *map[string]interface{} or a *[]map[string]interface{} if the columns are unknown,see Rows.Scan
 func (p *PgTable) Find(dest interface{}) error {
-	sql := p.parseSQL(opTypeQuery)
-	defer p.clear()
-	stmt, err := p.db.PrepareContext(p.ctx, sql.String())
-	if err != nil {
-		return fmt.Errorf("find:prepare sql error:%w", err)
-	}
-	row, err := stmt.QueryContext(p.ctx, p.filler...)
-	if err != nil {
-		return fmt.Errorf("find:query context:%w", err)
-	}
-	columns, err := row.Columns()
-	if err != nil {
-		return err
-	}
 	isSlice, err := p.checkIsSlice(dest)
 	if err != nil {
+		p.clear()
 		return fmt.Errorf("find:%w", err)
 	}
-	receiver := make([]interface{}, len(columns))
-	var destFieldLen int
-	var metaElem interface{}
-	if isSlice {
-		destType := reflect.Indirect(reflect.ValueOf(dest)).Type()
-		valueElem := reflect.New(destType.Elem())
-		metaElem = reflect.New(destType.Elem()).Interface()
-		destFieldLen = valueElem.Elem().NumField()
-	} else {
-		destFieldLen = reflect.TypeOf(dest).Elem().NumField()
-		metaElem = dest
-	}
-	var receiverMap = make(map[string]int)
-	for i, column := range columns {
-		var tmp string
-		receiver[i] = &tmp
-		receiverMap[column] = i
+	rows, err := p.open("find")
+	if err != nil {
+		return err
 	}
-	for i := 0; i < destFieldLen; i++ {
-		obj := reflect.TypeOf(metaElem).Elem().Field(i)
-		tag := obj.Tag.Get("json")
-		if tag == "" {
-			tag = obj.Name
-		}
-		receiverIdx, findOK := receiverMap[tag]
-		if !findOK {
-			continue
-		}
-		fieldType := reflect.ValueOf(metaElem).Elem().Field(i).Type().String()
-		switch fieldType {
-		case "int", "uint", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
-			var intMeta int64
-			receiver[receiverIdx] = &intMeta
-		case "float32", "float64":
-			var floatMeta float64
-			receiver[receiverIdx] = &floatMeta
-		case "time.Time":
-			var timeMeta = time.Time{}
-			receiver[receiverIdx] = &timeMeta
-		case "*time.Time":
-			var timeMeta = &time.Time{}
-			receiver[receiverIdx] = &timeMeta
-		case "*string":
-			var strMeta string
-			tmp := &strMeta
-			receiver[receiverIdx] = &tmp
-		case "bool":
-			var boolMeta bool
-			receiver[receiverIdx] = &boolMeta
-		default:
-			var strMeta string
-			receiver[receiverIdx] = &strMeta
+	defer rows.Close()
+	if !isSlice {
+		if rows.Next() {
+			if err = rows.Scan(dest); err != nil {
+				return fmt.Errorf("find:%w", err)
+			}
 		}
+		return rows.Err()
 	}
 	var out = reflect.Indirect(reflect.ValueOf(dest))
-	for row.Next() {
-		err = row.Scan(receiver...)
-		if err != nil {
-			return fmt.Errorf("find:scan record error:%w", err)
-		}
-		packJson := map[string]interface{}{}
-		for key, idx := range receiverMap {
-			packJson[key] = receiver[idx]
+	elemType := out.Type().Elem()
+	for rows.Next() {
+		var elem reflect.Value
+		if elemType.Kind() == reflect.Ptr {
+			elem = reflect.New(elemType.Elem())
+			err = rows.Scan(elem.Interface())
+		} else {
+			elem = reflect.New(elemType)
+			err = rows.Scan(elem.Interface())
+			elem = elem.Elem()
 		}
-		jsonByte, err := json.Marshal(packJson)
 		if err != nil {
-			return fmt.Errorf("find:json marshal error:%w", err)
+			return fmt.Errorf("find:%w", err)
 		}
-		if !isSlice {
-			if err := json.Unmarshal(jsonByte, dest); err != nil {
-				return fmt.Errorf("find:json unmarshal error:%w", err)
-			}
-			return nil
-		}
-		if err := json.Unmarshal(jsonByte, metaElem); err != nil {
-			return fmt.Errorf("find:json unmarshal error:%w", err)
-		}
-		out = reflect.Append(out, reflect.ValueOf(metaElem).Elem())
+		out = reflect.Append(out, elem)
 	}
-	reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(out.Interface()))
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	reflect.ValueOf(dest).Elem().Set(out)
 	return nil
 }
 
+//Count counts the rows,with Distinct/DistinctOn it counts the distinct values of the selected columns,
+//with Group it counts the groups
 func (p *PgTable) Count(count *int64) error {
-	if p.fields == "*" {
-		p.fields = "COUNT(*)"
+	if len(p.fields) == 0 && !p.distinct && len(p.distinctOn) == 0 && len(p.group) == 0 && len(p.having) == 0 {
+		p.fields = []*storage{{bucket: "COUNT(*)"}}
 	}
 	sql := p.parseSQL(opTypeCount)
 	defer p.clear()
-	stmt, err := p.db.PrepareContext(p.ctx, sql.String())
+	if p.err != nil {
+		return fmt.Errorf("count:%w", p.err)
+	}
+	stmt, err := p.executor().PrepareContext(p.ctx, sql.String())
 	if err != nil {
 		return fmt.Errorf("count:prepare sql error:%w", err)
 	}
@@ -301,44 +467,42 @@ func (p *PgTable) Count(count *int64) error {
 	return nil
 }
 
-func (p *PgTable) Sum(sum *int64) error {
-	if p.fields == "*" {
-		return fmt.Errorf("sum:please use 'Select(fieldName)' to set the sum field")
-	}
-	sql := p.parseSQL(opTypeSum)
-	defer p.clear()
-	stmt, err := p.db.PrepareContext(p.ctx, sql.String())
-	if err != nil {
-		return fmt.Errorf("sum:prepare sql error:%w", err)
-	}
-	if err = stmt.QueryRowContext(p.ctx, p.filler...).Scan(sum); err != nil {
-		return fmt.Errorf("sum:query row context error:%w", err)
-	}
-	return nil
+//Sum sums the selected field into dest,see Max for the supported destinations
+func (p *PgTable) Sum(sum interface{}) error {
+	return p.aggregate(opTypeSum, sum)
 }
 
-func (p *PgTable) Avg(avg *int64) error {
-	if p.fields == "*" {
-		return fmt.Errorf("avg:please use 'Select(fieldName)' to set the avg field")
-	}
-	sql := p.parseSQL(opTypeSum)
+//Avg averages the selected field into dest,use *float64 or *string (decimal) to keep the fraction
+func (p *PgTable) Avg(avg interface{}) error {
+	return p.aggregate(opTypeAvg, avg)
+}
+
+func (p *PgTable) Update(dest interface{}) error {
 	defer p.clear()
-	stmt, err := p.db.PrepareContext(p.ctx, sql.String())
+	sqlStr, err := p.buildUpdate(dest)
 	if err != nil {
-		return fmt.Errorf("avg:prepare sql error:%w", err)
+		return fmt.Errorf("update:%w", err)
 	}
-	if err = stmt.QueryRowContext(p.ctx, p.filler...).Scan(avg); err != nil {
-		return fmt.Errorf("avg:query row context error:%w", err)
+	stmt, err := p.executor().PrepareContext(p.ctx, sqlStr)
+	if err != nil {
+		return fmt.Errorf("update:prepare sql error:%w", err)
 	}
+	if _, err = stmt.ExecContext(p.ctx, p.filler...); err != nil {
+		return fmt.Errorf("update:exec context:%w", err)
+	}
+
 	return nil
 }
 
-func (p *PgTable) Update(dest interface{}) error {
+//buildUpdate builds the UPDATE statement,the values are appended to the filler
+func (p *PgTable) buildUpdate(dest interface{}) (string, error) {
 	sql := p.parseSQL(opTypeSave)
-	defer p.clear()
+	if p.err != nil {
+		return "", p.err
+	}
 	isMap, err := p.checkUpdateType(dest)
 	if err != nil {
-		fmt.Errorf("update:%w", err)
+		return "", err
 	}
 	var fieldList, valueList []string
 	var updateNum = p.storageCursor
@@ -351,7 +515,7 @@ func (p *PgTable) Update(dest interface{}) error {
 			key := ranger.Key().Interface()
 			value := ranger.Value().Interface()
 			if realKey, ok := key.(string); ok {
-				fieldList = append(fieldList, fmt.Sprintf("\"%s\"", realKey))
+				fieldList = append(fieldList, quotePart(realKey))
 			}
 			valueList = append(valueList, fmt.Sprintf("$%d", updateNum))
 			p.filler = append(p.filler, value)
@@ -379,23 +543,38 @@ func (p *PgTable) Update(dest interface{}) error {
 
 	sqlStr := strings.ReplaceAll(sql.String(), "$FIELDS", fmt.Sprintf("(%s)", strings.Join(fieldList, ",")))
 	sqlStr = strings.ReplaceAll(sqlStr, "$VALUES", fmt.Sprintf("(%s)", strings.Join(valueList, ",")))
-	stmt, err := p.db.PrepareContext(p.ctx, sqlStr)
+	p.storageCursor = updateNum
+	return sqlStr, nil
+}
+
+func (p *PgTable) Save(dest interface{}) error {
+	defer p.clear()
+	sqlStr, err := p.buildSave(dest)
 	if err != nil {
-		return fmt.Errorf("update:prepare sql error:%w", err)
+		return fmt.Errorf("save:%w", err)
+	}
+	stmt, err := p.executor().PrepareContext(p.ctx, sqlStr)
+	if err != nil {
+		return fmt.Errorf("save:prepare sql error:%w", err)
 	}
 	if _, err = stmt.ExecContext(p.ctx, p.filler...); err != nil {
-		return fmt.Errorf("update:exec context:%w", err)
+		return fmt.Errorf("save:exec context:%w", err)
 	}
-
 	return nil
 }
 
-func (p *PgTable) Save(dest interface{}) error {
+//buildSave builds the INSERT statement,the values are appended to the filler
+func (p *PgTable) buildSave(dest interface{}) (string, error) {
 	sql := p.parseSQL(opTypeCreate)
-	defer p.clear()
+	if p.err != nil {
+		return "", p.err
+	}
 	isSlice, err := p.checkIsSlice(dest)
 	if err != nil {
-		fmt.Errorf("save:%w", err)
+		return "", err
+	}
+	if isRowMap(dest) {
+		return "", fmt.Errorf("map destinations are only supported by Find/Each")
 	}
 	var metaElem interface{}
 	var rowsNum int
@@ -410,9 +589,9 @@ func (p *PgTable) Save(dest interface{}) error {
 		rowsNum = reflect.TypeOf(dest).Elem().NumField()
 		metaElem = dest
 	}
-	var curColumnsNum, insertNum int
+	var curColumnsNum int
+	var insertNum = p.storageCursor
 	var fieldList, valueList []string
-	var insertArgs []interface{}
 	for curColumnsNum < columnsNum {
 		var curRowsNum int
 		var curValueList []string
@@ -434,7 +613,7 @@ func (p *PgTable) Save(dest interface{}) error {
 				curValueList = append(curValueList, "DEFAULT")
 				insertNum--
 			} else {
-				insertArgs = append(insertArgs, reflect.ValueOf(metaElem).Elem().Field(curRowsNum).Interface())
+				p.filler = append(p.filler, reflect.ValueOf(metaElem).Elem().Field(curRowsNum).Interface())
 				curValueList = append(curValueList, fmt.Sprintf("$%d", insertNum))
 			}
 			curRowsNum++
@@ -445,23 +624,17 @@ func (p *PgTable) Save(dest interface{}) error {
 
 	sqlStr := strings.ReplaceAll(sql.String(), "$FIELDS", fmt.Sprintf("(%s)", strings.Join(fieldList, ",")))
 	sqlStr = strings.ReplaceAll(sqlStr, "$VALUES", fmt.Sprintf("(%s)", strings.Join(valueList, "),(")))
-	stmt, err := p.db.PrepareContext(p.ctx, sqlStr)
-	if err != nil {
-		return fmt.Errorf("save:prepare sql error:%w", err)
-	}
-	if _, err = stmt.ExecContext(p.ctx, insertArgs...); err != nil {
-		return fmt.Errorf("save:exec context:%w", err)
-	}
-	return nil
+	p.storageCursor = insertNum
+	return sqlStr, nil
 }
 
 func (p *PgTable) Delete() error {
-	sql := p.parseSQL(opTypeDelete)
 	defer p.clear()
-	if p.where == nil {
-		return fmt.Errorf("delete:must have deletion condition")
+	sqlStr, err := p.buildDelete()
+	if err != nil {
+		return fmt.Errorf("delete:%w", err)
 	}
-	stmt, err := p.db.PrepareContext(p.ctx, sql.String())
+	stmt, err := p.executor().PrepareContext(p.ctx, sqlStr)
 	if err != nil {
 		return fmt.Errorf("delete:prepare sql error:%w", err)
 	}
@@ -472,11 +645,27 @@ func (p *PgTable) Delete() error {
 	return nil
 }
 
+//buildDelete builds the DELETE statement,the conditions are required
+func (p *PgTable) buildDelete() (string, error) {
+	if p.where == nil {
+		return "", fmt.Errorf("must have deletion condition")
+	}
+	sql := p.parseSQL(opTypeDelete)
+	if p.err != nil {
+		return "", p.err
+	}
+	return sql.String(), nil
+}
+
 func (p *PgTable) SetInc(field string) error {
+	field = p.checkIdent(field)
 	sql := p.parseSQL(opTypeSaveInt)
 	defer p.clear()
+	if p.err != nil {
+		return fmt.Errorf("save inc:%w", p.err)
+	}
 	sqlStr := strings.ReplaceAll(sql.String(), "$FIELDS", field)
-	stmt, err := p.db.PrepareContext(p.ctx, sqlStr)
+	stmt, err := p.executor().PrepareContext(p.ctx, sqlStr)
 	if err != nil {
 		return fmt.Errorf("save inc:prepare sql error:%w", err)
 	}
@@ -487,10 +676,14 @@ func (p *PgTable) SetInc(field string) error {
 }
 
 func (p *PgTable) SetDec(field string) error {
+	field = p.checkIdent(field)
 	sql := p.parseSQL(opTypeSaveDec)
 	defer p.clear()
+	if p.err != nil {
+		return fmt.Errorf("save dec:%w", p.err)
+	}
 	sqlStr := strings.ReplaceAll(sql.String(), "$FIELDS", field)
-	stmt, err := p.db.PrepareContext(p.ctx, sqlStr)
+	stmt, err := p.executor().PrepareContext(p.ctx, sqlStr)
 	if err != nil {
 		return fmt.Errorf("save dec:prepare sql error:%w", err)
 	}
@@ -501,24 +694,28 @@ func (p *PgTable) SetDec(field string) error {
 }
 
 func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
+	if len(p.compound) != 0 && op.(opType) != opTypeQuery {
+		p.setErr(fmt.Errorf("compound:only Find can be used with Union/Intersect/Except"))
+	}
+	if p.lock != nil && op.(opType) == opTypeQuery && (len(p.compound) != 0 || p.distinct || len(p.distinctOn) != 0 ||
+		len(p.group) != 0 || len(p.having) != 0 || len(p.windows) != 0 || p.funcColumns) {
+		p.setErr(fmt.Errorf("lock:%s can't be used with Union/Intersect/Except,DISTINCT,GROUP BY,WINDOW or the window/aggregate functions", p.lock.strength))
+	}
+	if p.raw != nil && op.(opType) == opTypeQuery && p.plainRaw() {
+		cond.WriteString(p.bind(p.raw.bucket, p.raw.argc))
+		return
+	}
+	with := p.parseWith()
+	cond.Write(with.Bytes())
 	tableName := p.parseTableName()
+	tableName.WriteString(p.parseSample(op.(opType)))
 	switch op.(opType) {
 	case opTypeQuery:
-		cond.WriteString("SELECT ")
-		cond.WriteString(p.fields)
-		cond.WriteString(" FROM ")
-		cond.Write(tableName.Bytes())
-		where := p.parseWhere()
-		if where.Len() != 0 {
-			cond.WriteString(" WHERE ")
-			cond.Write(where.Bytes())
-		}
-		if p.group != "" {
-			cond.WriteString(" GROUP BY ")
-			cond.WriteString(p.group)
-		}
-		if p.sort.Len() != 0 {
-			cond.WriteString(p.sort.String())
+		cond.WriteString(p.parseCompound(p.parseCore(tableName)))
+		sort := p.parseSort()
+		if sort.Len() != 0 {
+			cond.WriteString(" ORDER BY ")
+			cond.Write(sort.Bytes())
 		}
 		if p.offset > 0 {
 			cond.WriteString(" OFFSET ")
@@ -528,9 +725,16 @@ func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
 			cond.WriteString(" LIMIT ")
 			cond.WriteString(strconv.FormatInt(p.limit, 10))
 		}
+		cond.WriteString(p.parseLock())
 	case opTypeCount:
+		if p.distinct || len(p.distinctOn) != 0 || len(p.group) != 0 || len(p.having) != 0 {
+			cond.WriteString("SELECT COUNT(*) FROM (")
+			cond.WriteString(p.parseCore(tableName))
+			cond.WriteString(`) AS "t"`)
+			break
+		}
 		cond.WriteString("SELECT ")
-		cond.WriteString(p.fields)
+		cond.WriteString(p.parseFields())
 		cond.WriteString(" FROM ")
 		cond.Write(tableName.Bytes())
 		where := p.parseWhere()
@@ -538,19 +742,10 @@ func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
 			cond.WriteString(" WHERE ")
 			cond.Write(where.Bytes())
 		}
-	case opTypeSum:
-		cond.WriteString("SELECT SUM(")
-		cond.WriteString(p.fields)
-		cond.WriteString(") FROM ")
-		cond.Write(tableName.Bytes())
-		where := p.parseWhere()
-		if where.Len() != 0 {
-			cond.WriteString(" WHERE ")
-			cond.Write(where.Bytes())
-		}
-	case opTypeAvg:
-		cond.WriteString("SELECT AVG(")
-		cond.WriteString(p.fields)
+	case opTypeSum, opTypeAvg, opTypeMax, opTypeMin, opTypeCountDistinct:
+		cond.WriteString("SELECT ")
+		cond.WriteString(aggregates[op.(opType)].fn)
+		cond.WriteString(p.parseFields())
 		cond.WriteString(") FROM ")
 		cond.Write(tableName.Bytes())
 		where := p.parseWhere()
@@ -562,6 +757,7 @@ func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
 		cond.WriteString("INSERT INTO ")
 		cond.Write(tableName.Bytes())
 		cond.WriteString("$FIELDS VALUES $VALUES")
+		cond.WriteString(p.parseReturning())
 	case opTypeSave:
 		cond.WriteString("UPDATE ")
 		cond.Write(tableName.Bytes())
@@ -571,6 +767,7 @@ func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
 			cond.WriteString(" WHERE ")
 			cond.Write(where.Bytes())
 		}
+		cond.WriteString(p.parseReturning())
 	case opTypeDelete:
 		cond.WriteString("DELETE FROM ")
 		cond.Write(tableName.Bytes())
@@ -579,6 +776,7 @@ func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
 			cond.WriteString(" WHERE ")
 			cond.Write(where.Bytes())
 		}
+		cond.WriteString(p.parseReturning())
 	case opTypeSaveInt:
 		cond.WriteString("UPDATE ")
 		cond.Write(tableName.Bytes())
@@ -601,39 +799,352 @@ func (p *PgTable) parseSQL(op interface{}) (cond bytes.Buffer) {
 	return
 }
 
+//parseCore renders the SELECT without ORDER BY/OFFSET/LIMIT
+func (p *PgTable) parseCore(tableName bytes.Buffer) string {
+	var core bytes.Buffer
+	core.WriteString("SELECT ")
+	core.WriteString(p.parseDistinct())
+	core.WriteString(p.parseFields())
+	core.WriteString(" FROM ")
+	core.Write(tableName.Bytes())
+	where := p.parseWhere()
+	if where.Len() != 0 {
+		core.WriteString(" WHERE ")
+		core.Write(where.Bytes())
+	}
+	if len(p.group) != 0 {
+		core.WriteString(" GROUP BY ")
+		core.WriteString(p.parseStorages(p.group))
+	}
+	if len(p.having) != 0 {
+		core.WriteString(" HAVING ")
+		core.WriteString(p.parseConditions(p.having))
+	}
+	core.WriteString(p.parseWindows())
+	return core.String()
+}
+
+//parseTableName quotes each part of the table name,eg: billing.invoices => "billing"."invoices"
 func (p *PgTable) parseTableName() (cond bytes.Buffer) {
-	// only mysql can use it
-	//if p.meta.dbName != "" {
-	//	cond.WriteString("`")
-	//	cond.WriteString(p.dbName)
-	//	cond.WriteString("`.")
-	//}
-	if p.meta.tableName != "" {
-		cond.WriteString(`"`)
-		cond.WriteString(p.tableName)
-		cond.WriteString(`"`)
+	if p.meta.raw != nil {
+		cond.WriteString("(")
+		cond.WriteString(p.bind(p.raw.bucket, p.raw.argc))
+		//the newline ends a trailing "--" comment of the raw query
+		cond.WriteString("\n) AS \"t\"")
+		return
+	}
+	if p.meta.from != nil {
+		cond.WriteString(p.bind(p.from.bucket, p.from.argc))
+		return
+	}
+	if p.meta.tableName == "" {
+		return
+	}
+	parts, err := splitIdent(p.tableName)
+	if err == nil && (len(parts) > 3 || parts[len(parts)-1] == "*") {
+		err = fmt.Errorf("table:invalid table name '%s'", p.tableName)
+	}
+	if err != nil {
+		if p.strict {
+			p.setErr(fmt.Errorf("table:invalid table name '%s'", p.tableName))
+		}
+		// the old behavior,the whole name is treated as one identifier
+		cond.WriteString(quotePart(p.tableName))
+		return
+	}
+	if len(parts) == 1 && !p.cteNames[parts[0]] {
+		schema := p.meta.schema
+		if schema == "" {
+			schema = p.Pg.schema
+		}
+		if schema != "" {
+			if schemaParts, err := splitIdent(schema); err != nil || len(schemaParts) != 1 || schemaParts[0] == "*" {
+				p.setErr(fmt.Errorf("table:invalid schema '%s'", schema))
+			} else {
+				cond.WriteString(quotePart(schemaParts[0]))
+				cond.WriteString(".")
+			}
+		}
+	}
+	for i, part := range parts {
+		if i != 0 {
+			cond.WriteString(".")
+		}
+		cond.WriteString(quotePart(part))
 	}
 	return
 }
 
 func (p *PgTable) parseWhere() (cond bytes.Buffer) {
-	if p.meta.where != nil {
-		for _, row := range p.meta.where {
-			p.storageCursor++
-			if row.storageType == storageTypeWhereOr && cond.Len() != 0 {
-				cond.WriteString(" OR ")
+	cond.WriteString(p.parseConditions(p.meta.where))
+	return
+}
+
+//andWhere returns a copy of the conditions with cond appended by AND,the existing conditions are wrapped
+//in parentheses so an OR among them can't bypass cond,eg: (a = $1 OR b = $2) AND "id" > $3
+func andWhere(where []*storage, cond *storage) []*storage {
+	list := make([]*storage, 0, len(where)+1)
+	for i, row := range where {
+		wrapped := *row
+		if i == 0 {
+			wrapped.bucket = "(" + wrapped.bucket
+		}
+		if i == len(where)-1 {
+			wrapped.bucket += ")"
+		}
+		list = append(list, &wrapped)
+	}
+	cond.storageType = storageTypeWhereAnd
+	return append(list, cond)
+}
+
+//parseConditions joins the conditions of WHERE/HAVING with AND/OR
+func (p *PgTable) parseConditions(list []*storage) string {
+	var cond bytes.Buffer
+	for _, row := range list {
+		if row.storageType == storageTypeWhereOr && cond.Len() != 0 {
+			cond.WriteString(" OR ")
+		}
+		if row.storageType == storageTypeWhereAnd && cond.Len() != 0 {
+			cond.WriteString(" AND ")
+		}
+		cond.WriteString(p.bind(row.bucket, row.argc))
+	}
+	return cond.String()
+}
+
+func (p *PgTable) parseSort() (cond bytes.Buffer) {
+	for i, row := range p.sortWithDistinctOn() {
+		if i != 0 {
+			cond.WriteString(", ")
+		}
+		cond.WriteString(p.bind(row.bucket, row.argc))
+	}
+	return
+}
+
+//parseIdent quotes the identifier in strict mode,otherwise it is used verbatim
+func (p *PgTable) parseIdent(name string) string {
+	if !p.strict {
+		return name
+	}
+	quoted, err := QuoteIdent(name)
+	if err != nil {
+		p.setErr(err)
+	}
+	return quoted
+}
+
+//checkIdent validates the identifier even if the strict mode is off,it is quoted in strict mode only
+func (p *PgTable) checkIdent(name string) string {
+	if p.strict {
+		return p.parseIdent(name)
+	}
+	if _, err := splitIdent(name); err != nil {
+		p.setErr(err)
+	}
+	return name
+}
+
+//parseSortColumn converts the column passed to Sort/SortNulls
+func (p *PgTable) parseSortColumn(column interface{}) string {
+	switch c := column.(type) {
+	case string:
+		return p.checkIdent(c)
+	case Ident:
+		quoted, err := QuoteIdent(string(c))
+		if err != nil {
+			p.setErr(err)
+		}
+		return quoted
+	case Raw:
+		return string(c)
+	default:
+		p.setErr(fmt.Errorf("sort:unsupported column type %T", column))
+		return ""
+	}
+}
+
+//parseGroup converts the columns passed to Group/DistinctOn,the plain strings are column lists without aliases,
+//each column of the list is one storage
+func (p *PgTable) parseGroup(columns []interface{}) (list []*storage) {
+	for _, column := range columns {
+		c, ok := column.(string)
+		if !ok {
+			list = append(list, p.parseColumns([]interface{}{column})...)
+			continue
+		}
+		if c == "*" || strings.TrimSpace(c) == "" {
+			continue
+		}
+		for _, name := range strings.Split(c, ",") {
+			list = append(list, &storage{bucket: p.checkIdent(strings.TrimSpace(name))})
+		}
+	}
+	return
+}
+
+//parseColumns converts the columns passed to Select/Group,plain strings are validated in strict mode
+func (p *PgTable) parseColumns(columns []interface{}) (list []*storage) {
+	for _, column := range columns {
+		switch c := column.(type) {
+		case Raw:
+			list = append(list, &storage{bucket: string(c)})
+		case Ident:
+			quoted, err := QuoteIdent(string(c))
+			if err != nil {
+				p.setErr(err)
+			}
+			list = append(list, &storage{bucket: quoted})
+		case string:
+			if c == "*" || c == "" {
+				continue
 			}
-			if row.storageType == storageTypeWhereAnd && cond.Len() != 0 {
-				cond.WriteString(" AND ")
+			if !p.strict {
+				list = append(list, &storage{bucket: c})
+				continue
 			}
-			tag := fmt.Sprintf("$%d", p.storageCursor)
-			cond.WriteString(strings.ReplaceAll(row.bucket, "?", tag))
-			p.filler = append(p.filler, row.argc...)
+			quoted, err := quoteColumns(c)
+			if err != nil {
+				p.setErr(err)
+			}
+			list = append(list, &storage{bucket: quoted})
+		case *WindowFunc:
+			list = append(list, &storage{bucket: p.parseWindowFunc(c)})
+		case *AggregateFunc:
+			list = append(list, p.parseAggregateFunc(c))
+		case *alias:
+			parts, err := splitIdent(c.name)
+			if err != nil || len(parts) != 1 || parts[0] == "*" {
+				p.setErr(fmt.Errorf("columns:invalid alias '%s'", c.name))
+				continue
+			}
+			for _, row := range p.parseColumns([]interface{}{c.column}) {
+				row.bucket += " AS " + quotePart(parts[0])
+				list = append(list, row)
+			}
+		default:
+			if _, ok := subqueryOf(column); ok {
+				list = append(list, &storage{bucket: "?", argc: []interface{}{column}})
+				continue
+			}
+			p.setErr(fmt.Errorf("columns:unsupported column type %T", column))
 		}
 	}
 	return
 }
 
+//parseFields renders the selected columns,"*" is used if nothing is selected
+func (p *PgTable) parseFields() string {
+	if len(p.fields) == 0 {
+		return "*"
+	}
+	return p.parseStorages(p.fields)
+}
+
+//parseStorages renders a column list,the fragments without arguments are written verbatim
+func (p *PgTable) parseStorages(list []*storage) string {
+	var cond bytes.Buffer
+	for i, row := range list {
+		if i != 0 {
+			cond.WriteString(",")
+		}
+		if len(row.argc) == 0 {
+			cond.WriteString(row.bucket)
+			continue
+		}
+		cond.WriteString(p.bind(row.bucket, row.argc))
+	}
+	return cond.String()
+}
+
+//parseSubquery renders the subquery inside the current statement,its placeholders continue the numbering of
+//the current statement and its arguments are appended to the current filler
+func (p *PgTable) parseSubquery(sub *PgTable) string {
+	return p.parseNested(sub, func(sub *PgTable) (string, error) {
+		sql := sub.parseSQL(opTypeQuery)
+		return sql.String(), sub.err
+	})
+}
+
+//parseNested renders a statement of another builder with the numbering and the filler of the current statement,
+//a copy of the builder is used,so the same subquery can be rendered by several statements at the same time
+func (p *PgTable) parseNested(sub *PgTable, build func(sub *PgTable) (string, error)) string {
+	sub = sub.clone()
+	sub.storageCursor = p.storageCursor
+	sub.cteNames = p.cteNames
+	sql, err := build(sub)
+	p.storageCursor = sub.storageCursor
+	p.filler = append(p.filler, sub.filler...)
+	if err != nil {
+		p.setErr(fmt.Errorf("subquery:%w", err))
+	}
+	return sql
+}
+
+//bind replaces every "?" in expr with the next "$n" placeholder and appends the matching argument to the filler,
+//the "?" in the string literals,the quoted identifiers and the comments are skipped like bindNamed,
+//named placeholders (:name or @name) are used instead if the only argument is a map or a struct,see bindNamed
+func (p *PgTable) bind(expr string, argc []interface{}) string {
+	if args, ok := namedArgs(argc); ok {
+		if cond, found := p.bindNamed(expr, args); found {
+			return cond
+		}
+	}
+	var cond bytes.Buffer
+	var argIdx int
+	for i := 0; i < len(expr); i++ {
+		if end := skipLiteral(expr, i); end != i {
+			cond.WriteString(expr[i:end])
+			i = end - 1
+			continue
+		}
+		if expr[i] != '?' {
+			cond.WriteByte(expr[i])
+			continue
+		}
+		if argIdx >= len(argc) {
+			p.setErr(fmt.Errorf("bind:not enough arguments for '%s'", expr))
+			return cond.String()
+		}
+		if sub, ok := subqueryOf(argc[argIdx]); ok {
+			cond.WriteString("(")
+			cond.WriteString(p.parseSubquery(sub))
+			cond.WriteString(")")
+			argIdx++
+			continue
+		}
+		p.storageCursor++
+		cond.WriteString("$")
+		cond.WriteString(strconv.Itoa(p.storageCursor))
+		p.filler = append(p.filler, argc[argIdx])
+		argIdx++
+	}
+	if argIdx != len(argc) {
+		p.setErr(fmt.Errorf("bind:too many arguments for '%s'", expr))
+	}
+	return cond.String()
+}
+
+//setErr keeps the first error raised while building,it is returned by the final operation
+func (p *PgTable) setErr(err error) {
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+//jsonName returns the column name of the struct field,it is the name of the json tag or the field name
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if idx := strings.Index(tag, ","); idx != -1 {
+		tag = tag[:idx]
+	}
+	if tag == "" {
+		return field.Name
+	}
+	return tag
+}
+
 func (p *PgTable) checkIsSlice(dest interface{}) (isSlice bool, err error) {
 	switch reflect.TypeOf(dest).Kind() {
 	case reflect.Ptr:
@@ -642,12 +1153,17 @@ func (p *PgTable) checkIsSlice(dest interface{}) (isSlice bool, err error) {
 			isSlice = true
 		case reflect.Struct:
 			isSlice = false
-		//case reflect.Map:
-		//	isSlice = false
+		case reflect.Map:
+			isSlice = false
 		default:
 			err = fmt.Errorf("checkIsSlice:dest must be a slice/struct pointer")
 			return
 		}
+		if typ := reflect.TypeOf(dest).Elem(); (typ.Kind() == reflect.Map && typ != rowMapType) ||
+			(isSlice && typ.Elem().Kind() == reflect.Map && typ.Elem() != rowMapType) {
+			err = fmt.Errorf("checkIsSlice:the map must be map[string]interface{}")
+			return
+		}
 	default:
 		err = fmt.Errorf("checkIsSlice:dest must be a slice/struct pointer")
 		return
@@ -674,14 +1190,92 @@ func (p *PgTable) checkUpdateType(dest interface{}) (isMap bool, err error) {
 	return
 }
 
+//alias is a column with alias,it is created by As(...)
+type alias struct {
+	column interface{}
+	name   string
+}
+
+//As gives the column an alias,the column can be anything accepted by Select,eg: As(db.Table("b").Select("COUNT(*)"), "total")
+func As(column interface{}, name string) interface{} {
+	return &alias{column: column, name: name}
+}
+
+//subqueryOf returns the builder if the argument is a Table/Query
+func subqueryOf(arg interface{}) (*PgTable, bool) {
+	switch sub := arg.(type) {
+	case *PgTable:
+		return sub, sub != nil
+	case *PgQuery:
+		return sub.table, sub != nil
+	}
+	return nil, false
+}
+
 type PgQuery struct {
 	*Pg
 }
 
-func (p *PgQuery) Sort(filed string, sortBy string) Query {
+func (p *PgQuery) Sort(filed interface{}, sortBy string) Query {
 	return p.table.Sort(filed, sortBy)
 }
 
+func (p *PgQuery) SortNulls(filed interface{}, order SortOrder, nulls Nulls) Query {
+	return p.table.SortNulls(filed, order, nulls)
+}
+
+func (p *PgQuery) SortExpr(expr interface{}, order SortOrder, nulls Nulls, argc ...interface{}) Query {
+	return p.table.SortExpr(expr, order, nulls, argc...)
+}
+
+func (p *PgQuery) Union(query Query) Query {
+	return p.table.Union(query)
+}
+
+func (p *PgQuery) UnionAll(query Query) Query {
+	return p.table.UnionAll(query)
+}
+
+func (p *PgQuery) Intersect(query Query) Query {
+	return p.table.Intersect(query)
+}
+
+func (p *PgQuery) Except(query Query) Query {
+	return p.table.Except(query)
+}
+
+func (p *PgQuery) ForUpdate(of ...string) Query {
+	return p.table.ForUpdate(of...)
+}
+
+func (p *PgQuery) ForNoKeyUpdate(of ...string) Query {
+	return p.table.ForNoKeyUpdate(of...)
+}
+
+func (p *PgQuery) ForShare(of ...string) Query {
+	return p.table.ForShare(of...)
+}
+
+func (p *PgQuery) ForKeyShare(of ...string) Query {
+	return p.table.ForKeyShare(of...)
+}
+
+func (p *PgQuery) SkipLocked() Query {
+	return p.table.SkipLocked()
+}
+
+func (p *PgQuery) NoWait() Query {
+	return p.table.NoWait()
+}
+
+func (p *PgQuery) Sample(method SampleMethod, percent float64, seed ...int64) Query {
+	return p.table.Sample(method, percent, seed...)
+}
+
+func (p *PgQuery) SampleRandom(n int64) Query {
+	return p.table.SampleRandom(n)
+}
+
 func (p *PgQuery) Offset(offset int64) Query {
 	return p.table.Offset(offset)
 }
@@ -690,23 +1284,122 @@ func (p *PgQuery) Limit(limit int64) Query {
 	return p.table.Limit(limit)
 }
 
-func (p *PgQuery) Group(group string) Query {
-	p.group = group
-	return p.query
+func (p *PgQuery) Group(group ...interface{}) Query {
+	return p.table.Group(group...)
+}
+
+func (p *PgQuery) Having(having string, argc ...interface{}) Query {
+	return p.table.Having(having, argc...)
+}
+
+func (p *PgQuery) HavingOr(having string, argc ...interface{}) Query {
+	return p.table.HavingOr(having, argc...)
+}
+
+func (p *PgQuery) FindMap(dest interface{}) error {
+	return p.table.FindMap(dest)
+}
+
+func (p *PgQuery) First(dest interface{}) error {
+	return p.table.First(dest)
+}
+
+func (p *PgQuery) Last(dest interface{}) error {
+	return p.table.Last(dest)
+}
+
+func (p *PgQuery) Take(dest interface{}) error {
+	return p.table.Take(dest)
+}
+
+func (p *PgQuery) FindByPK(dest interface{}, id interface{}) error {
+	return p.table.FindByPK(dest, id)
+}
+
+func (p *PgQuery) Pluck(column interface{}, dest interface{}) error {
+	return p.table.Pluck(column, dest)
+}
+
+func (p *PgQuery) Exists() (bool, error) {
+	return p.table.Exists()
 }
 
 func (p *PgQuery) Find(dest interface{}) error {
 	return p.table.Find(dest)
 }
 
+func (p *PgQuery) Cursor(batch int64) Query {
+	return p.table.Cursor(batch)
+}
+
+func (p *PgQuery) Rows() (*Rows, error) {
+	return p.table.Rows()
+}
+
+func (p *PgQuery) Each(dest interface{}, f func() error) error {
+	return p.table.Each(dest, f)
+}
+
 func (p *PgQuery) Count(count *int64) error {
 	return p.table.Count(count)
 }
 
-func (p *PgQuery) Sum(sum *int64) error {
+func (p *PgQuery) EstimateCount(count *int64) error {
+	return p.table.EstimateCount(count)
+}
+
+func (p *PgQuery) SmartCount(threshold int64, count *int64) error {
+	return p.table.SmartCount(threshold, count)
+}
+
+func (p *PgQuery) Sum(sum interface{}) error {
 	return p.table.Sum(sum)
 }
 
-func (p *PgQuery) Avg(avg *int64) error {
+func (p *PgQuery) Avg(avg interface{}) error {
 	return p.table.Avg(avg)
 }
+
+func (p *PgQuery) Max(max interface{}) error {
+	return p.table.Max(max)
+}
+
+func (p *PgQuery) Min(min interface{}) error {
+	return p.table.Min(min)
+}
+
+func (p *PgQuery) CountDistinct(count interface{}) error {
+	return p.table.CountDistinct(count)
+}
+
+func (p *PgQuery) Aggregate(dest interface{}) error {
+	return p.table.Aggregate(dest)
+}
+
+func (p *PgQuery) Percentile(column string, fractions ...float64) ([]float64, error) {
+	return p.table.Percentile(column, fractions...)
+}
+
+func (p *PgQuery) Stddev(column string) (float64, error) {
+	return p.table.Stddev(column)
+}
+
+func (p *PgQuery) Histogram(column string, min, max float64, count int) ([]Bucket, error) {
+	return p.table.Histogram(column, min, max, count)
+}
+
+func (p *PgQuery) TimeSeries(column, unit string, config ...SeriesConfig) ([]TimeBucket, error) {
+	return p.table.TimeSeries(column, unit, config...)
+}
+
+func (p *PgQuery) Keyset(cursor string, size int64, dest interface{}) (*KeysetPage, error) {
+	return p.table.Keyset(cursor, size, dest)
+}
+
+func (p *PgQuery) Paginate(page, size int64, dest interface{}) (*Page, error) {
+	return p.table.Paginate(page, size, dest)
+}
+
+func (p *PgQuery) PaginateConcurrently(page, size int64, dest interface{}) (*Page, error) {
+	return p.table.PaginateConcurrently(page, size, dest)
+}
@@ -3,7 +3,9 @@ package sql
 import (
 	"bytes"
 	"database/sql"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -265,3 +267,320 @@ func TestPgTable_Update(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+//fakePg builds a Pg without connection,it is used to test the sql builder
+func fakePg() *Pg {
+	p := &Pg{meta: &meta{}}
+	p.table = &PgTable{Pg: p}
+	p.query = &PgQuery{Pg: p}
+	return p
+}
+
+//parse builds the sql of the builder,it returns the sql,the filler and the error raised while building
+func parse(table Table, op opType) (string, []interface{}, error) {
+	builder := table.(*PgTable)
+	sql := builder.parseSQL(op)
+	return sql.String(), builder.filler, builder.err
+}
+
+func TestPgTable_Sort(t *testing.T) {
+	tests := []struct {
+		name   string
+		build  func(pg SQL) Table
+		want   string
+		filler []interface{}
+		err    bool
+	}{
+		{
+			name: "chained sorts",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Where("type=?", "normal").Sort("id", "desc").Sort("name", "asc")
+				return table
+			},
+			want:   `SELECT * FROM "app" WHERE type=$1 ORDER BY id DESC, name ASC`,
+			filler: []interface{}{"normal"},
+		},
+		{
+			name: "nulls and expression",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Where("type=?", "normal").
+					SortNulls("deleted_date", Desc, NullsLast).
+					SortExpr("position(? in name)", Asc, NullsDefault, "a")
+				return table
+			},
+			want:   `SELECT * FROM "app" WHERE type=$1 ORDER BY deleted_date DESC NULLS LAST, position($2 in name) ASC`,
+			filler: []interface{}{"normal", "a"},
+		},
+		{
+			name: "invalid direction",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Sort("id", "desc; DROP TABLE app")
+				return table
+			},
+			err: true,
+		},
+		{
+			name: "ident and raw",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Sort(Ident("app.id"), "desc").SortNulls(Raw("lower(name)"), Asc, NullsFirst)
+				return table
+			},
+			want: `SELECT * FROM "app" ORDER BY "app"."id" DESC, lower(name) ASC NULLS FIRST`,
+		},
+		{
+			name: "column payload",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Sort("id; DROP TABLE app--", "asc")
+				return table
+			},
+			err: true,
+		},
+		{
+			name: "group payload",
+			build: func(pg SQL) Table {
+				table := pg.Table("app")
+				table.Select("type").Group("type HAVING 1=1")
+				return table
+			},
+			err: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, filler, err := parse(tt.build(fakePg()), opTypeQuery)
+			if tt.err {
+				if err == nil {
+					t.Errorf("parseSQL() want error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
+
+func TestPgTable_Schema(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultSchema string
+		build         func(pg SQL) Table
+		want          string
+		wantErr       bool
+	}{
+		{
+			name: "schema qualified table",
+			build: func(pg SQL) Table {
+				return pg.Table("billing.invoices")
+			},
+			want: `SELECT * FROM "billing"."invoices"`,
+		},
+		{
+			name: "schema step",
+			build: func(pg SQL) Table {
+				return pg.Table("invoices").Schema("billing")
+			},
+			want: `SELECT * FROM "billing"."invoices"`,
+		},
+		{
+			name:          "default schema",
+			defaultSchema: "billing",
+			build: func(pg SQL) Table {
+				return pg.Table("invoices")
+			},
+			want: `SELECT * FROM "billing"."invoices"`,
+		},
+		{
+			name:          "schema step overrides default schema",
+			defaultSchema: "billing",
+			build: func(pg SQL) Table {
+				return pg.Table("invoices").Schema("archive")
+			},
+			want: `SELECT * FROM "archive"."invoices"`,
+		},
+		{
+			name:          "qualified table ignores default schema",
+			defaultSchema: "billing",
+			build: func(pg SQL) Table {
+				return pg.Table("public.invoices")
+			},
+			want: `SELECT * FROM "public"."invoices"`,
+		},
+		{
+			name: "invalid schema",
+			build: func(pg SQL) Table {
+				return pg.Table("invoices").Schema(`billing"."x`)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := fakePg()
+			pg.SetSchema(tt.defaultSchema)
+			got, _, err := parse(tt.build(pg), opTypeQuery)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPgTable_Subquery(t *testing.T) {
+	tests := []struct {
+		name   string
+		build  func(pg SQL) Table
+		want   string
+		filler []interface{}
+	}{
+		{
+			name: "where in subquery",
+			build: func(pg SQL) Table {
+				return pg.Table("app").
+					Where("type=?", "normal").
+					Where("id IN ?", pg.Table("app_user").Select("app_id").Where("user_id=?", 7)).
+					Where("name<>?", "x")
+			},
+			want:   `SELECT * FROM "app" WHERE type=$1 AND id IN (SELECT app_id FROM "app_user" WHERE user_id=$2) AND name<>$3`,
+			filler: []interface{}{"normal", 7, "x"},
+		},
+		{
+			name: "exists",
+			build: func(pg SQL) Table {
+				return pg.Table("app").Where("EXISTS ?", pg.Table("app_user").Select("1").Where("app_user.app_id=app.id"))
+			},
+			want: `SELECT * FROM "app" WHERE EXISTS (SELECT 1 FROM "app_user" WHERE app_user.app_id=app.id)`,
+		},
+		{
+			name: "selected subquery",
+			build: func(pg SQL) Table {
+				return pg.Table("app").
+					Select("id", As(pg.Table("app_user").Select("COUNT(*)").Where("app_user.app_id=app.id AND role=?", "admin"), "admins")).
+					Where("type=?", "normal")
+			},
+			want:   `SELECT id,(SELECT COUNT(*) FROM "app_user" WHERE app_user.app_id=app.id AND role=$1) AS "admins" FROM "app" WHERE type=$2`,
+			filler: []interface{}{"admin", "normal"},
+		},
+		{
+			name: "from subquery",
+			build: func(pg SQL) Table {
+				sub := pg.Table("app").Select("type", Raw("COUNT(*) AS total")).Where("id>?", 10).Group("type")
+				return pg.From(sub, "t").Where("total>?", 5)
+			},
+			want:   `SELECT * FROM (SELECT type,COUNT(*) AS total FROM "app" WHERE id>$1 GROUP BY type) AS "t" WHERE total>$2`,
+			filler: []interface{}{10, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, filler, err := parse(tt.build(fakePg()), opTypeQuery)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
+
+func TestPg_Transaction_fake(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name      string
+		f         func(tx SQL) error
+		err       error
+		panics    bool
+		commits   int
+		rollbacks int
+	}{
+		{
+			name:    "commit",
+			f:       func(tx SQL) error { return tx.Table("app").Where("id=?", 1).SetInc("hits") },
+			commits: 1,
+		},
+		{
+			name: "rollback on error",
+			f: func(tx SQL) error {
+				if err := tx.Table("app").Where("id=?", 1).SetInc("hits"); err != nil {
+					return err
+				}
+				return errFailed
+			},
+			err:       errFailed,
+			rollbacks: 1,
+		},
+		{
+			name: "rollback on panic",
+			f: func(tx SQL) error {
+				if err := tx.Table("app").Where("id=?", 1).SetInc("hits"); err != nil {
+					return err
+				}
+				panic(errFailed)
+			},
+			panics:    true,
+			rollbacks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, result := fakeDb(t, nil)
+			func() {
+				defer func() {
+					if r := recover(); (r != nil) != tt.panics {
+						t.Errorf("Transaction() panic = %v, want panic %v", r, tt.panics)
+					}
+				}()
+				if err := pg.Transaction(tt.f); !errors.Is(err, tt.err) {
+					t.Errorf("Transaction() error = %v, want %v", err, tt.err)
+				}
+			}()
+			if result.commits != tt.commits || result.rollbacks != tt.rollbacks {
+				t.Errorf("commits = %d, rollbacks = %d, want %d and %d", result.commits, result.rollbacks, tt.commits, tt.rollbacks)
+			}
+			if len(result.queries) != 1 || !strings.HasPrefix(result.queries[0], `UPDATE "app" SET`) {
+				t.Errorf("queries = %v", result.queries)
+			}
+		})
+	}
+}
+
+func TestPg_Transaction(t *testing.T) {
+	db, isFakeConn := conn()
+	if isFakeConn {
+		return
+	}
+	err := db.Transaction(func(tx SQL) error {
+		var apps []TestTable
+		if err := tx.Table("app").Where("type=?", "normal").Limit(5).ForUpdate().SkipLocked().Find(&apps); err != nil {
+			return err
+		}
+		for _, app := range apps {
+			if err := tx.Table("app").Where("id=?", app.Id).SetInc("id"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
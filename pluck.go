@@ -0,0 +1,73 @@
+package sql
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+//Pluck finds one column into a slice pointer of scalars (int,float,string,bool,time.Time,pointers) or sql.Scanner,
+//the column can be a string,an Ident,a Raw expression or an aggregate like Select
+//eg: var ids []int64; err := db.Table("app").Where("type=?", "normal").Pluck("id", &ids)
+func (p *PgTable) Pluck(column interface{}, dest interface{}) error {
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() || destValue.Elem().Kind() != reflect.Slice {
+		p.clear()
+		return fmt.Errorf("pluck:dest must be a slice pointer")
+	}
+	elemType := destValue.Elem().Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() == reflect.Struct && structType != reflect.TypeOf(time.Time{}) &&
+		!reflect.PtrTo(structType).Implements(scannerType) {
+		p.clear()
+		return fmt.Errorf("pluck:dest must be a slice of scalar,use Find for the structs")
+	}
+	p.fields = p.parseColumns([]interface{}{column})
+	if len(p.fields) != 1 {
+		p.clear()
+		return fmt.Errorf("pluck:please set one column")
+	}
+	rows, err := p.open("pluck")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	out := reflect.MakeSlice(destValue.Elem().Type(), 0, 0)
+	for rows.Next() {
+		receiver := scalarReceiver(elemType)
+		if err = rows.rows.Scan(receiver); err != nil {
+			return fmt.Errorf("pluck:scan record error:%w", err)
+		}
+		elem := reflect.New(elemType).Elem()
+		if err = setScalar(elem, receiver); err != nil {
+			return fmt.Errorf("pluck:%w", err)
+		}
+		out = reflect.Append(out, elem)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	destValue.Elem().Set(out)
+	return nil
+}
+
+//Exists checks whether the query has any row by SELECT EXISTS(...),the rows are not loaded
+//eg: exists, err := db.Table("user").Where("email=?", email).Exists()
+func (p *PgTable) Exists() (bool, error) {
+	p.sort = nil
+	p.lock = nil
+	p.cursorBatch = 0
+	query := p.parseSQL(opTypeQuery)
+	defer p.clear()
+	if p.err != nil {
+		return false, fmt.Errorf("exists:%w", p.err)
+	}
+	var exists bool
+	if err := p.executor().QueryRowContext(p.ctx, "SELECT EXISTS("+query.String()+")", p.filler...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("exists:query row context error:%w", err)
+	}
+	return exists, nil
+}
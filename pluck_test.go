@@ -0,0 +1,55 @@
+package sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_Pluck(t *testing.T) {
+	t.Run("ints", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(1)}, []driver.Value{"2"}, []driver.Value{nil})
+		var ids []int64
+		if err := pg.Table("app").Where("type=?", "normal").Pluck("id", &ids); err != nil {
+			t.Fatal(err)
+		}
+		if want := []int64{1, 2, 0}; !reflect.DeepEqual(ids, want) {
+			t.Errorf("Pluck() = %v, want %v", ids, want)
+		}
+		if want := `SELECT id FROM "app" WHERE type=$1`; result.queries[0] != want {
+			t.Errorf("Pluck() query = %v, want %v", result.queries[0], want)
+		}
+	})
+	t.Run("scanner", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"name"}, []driver.Value{"a"}, []driver.Value{nil})
+		var names []sql.NullString
+		if err := pg.Table("app").Pluck(Ident("name"), &names); err != nil {
+			t.Fatal(err)
+		}
+		if want := []sql.NullString{{String: "a", Valid: true}, {}}; !reflect.DeepEqual(names, want) {
+			t.Errorf("Pluck() = %v, want %v", names, want)
+		}
+	})
+	t.Run("struct", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"id"})
+		var apps []fakeApp
+		if err := pg.Table("app").Pluck("id", &apps); err == nil {
+			t.Error("Pluck() error = nil, want error")
+		}
+	})
+}
+
+func TestPgTable_Exists(t *testing.T) {
+	pg, result := fakeDb(t, []string{"exists"}, []driver.Value{true})
+	exists, err := pg.Table("user").Where("email=?", "a@b.c").Sort("id", "desc").Exists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Error("Exists() = false, want true")
+	}
+	if want := `SELECT EXISTS(SELECT * FROM "user" WHERE email=$1)`; result.queries[0] != want {
+		t.Errorf("Exists() query = %v, want %v", result.queries[0], want)
+	}
+}
@@ -0,0 +1,44 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+//Raw creates a builder of the hand-written query,"?" is replaced with "$n" like Where,so the jsonb operators ?,?| and ?&
+//can't be used,use the functions jsonb_exists,jsonb_exists_any and jsonb_exists_all instead,
+//the result is mapped like Table(...).Find/Pluck/Each,the named placeholders (:name or @name) are supported like Where,
+//the query is wrapped as a subquery (SELECT ... FROM (query) AS "t") if Sort/Limit/Pluck/Count... are used
+//eg: err := db.Raw("SELECT a.id, a.name FROM app a JOIN owner o ON o.id = a.owner_id WHERE o.name = ?", name).Find(&apps)
+func (p *Pg) Raw(query string, argc ...interface{}) Query {
+	table := p.builder(&meta{ctx: context.Background()})
+	table.raw = &storage{
+		bucket: strings.TrimSuffix(strings.TrimSpace(query), ";"),
+		argc:   argc,
+	}
+	return table.query
+}
+
+//Exec runs the hand-written statement,"?" is replaced with "$n" like Raw
+//eg: result, err := db.Exec("UPDATE app SET hits = hits + 1 WHERE id = ?", id)
+func (p *Pg) Exec(query string, argc ...interface{}) (sql.Result, error) {
+	builder := p.builder(&meta{ctx: context.Background()})
+	stmt := builder.bind(query, argc)
+	if builder.err != nil {
+		return nil, fmt.Errorf("exec:%w", builder.err)
+	}
+	result, err := builder.executor().ExecContext(builder.ctx, stmt, builder.filler...)
+	if err != nil {
+		return nil, fmt.Errorf("exec:exec context:%w", err)
+	}
+	return result, nil
+}
+
+//plainRaw reports whether the raw query can be run as it is
+func (p *PgTable) plainRaw() bool {
+	return len(p.fields) == 0 && len(p.where) == 0 && len(p.sort) == 0 && p.limit == 0 && p.offset == 0 &&
+		len(p.group) == 0 && len(p.having) == 0 && len(p.with) == 0 && len(p.compound) == 0 && p.lock == nil &&
+		!p.distinct && len(p.distinctOn) == 0 && len(p.windows) == 0 && p.sample == nil
+}
@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestPg_Raw(t *testing.T) {
+	query := "SELECT a.id, a.name FROM app a JOIN owner o ON o.id = a.owner_id WHERE o.name = ? AND a.type = ?;"
+	t.Run("find", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"id", "name"}, []driver.Value{int64(1), "a"})
+		var apps []fakeApp
+		if err := pg.Raw(query, "bob", "normal").Find(&apps); err != nil {
+			t.Fatal(err)
+		}
+		if len(apps) != 1 || apps[0].Name != "a" {
+			t.Errorf("Find() = %+v", apps)
+		}
+		want := "SELECT a.id, a.name FROM app a JOIN owner o ON o.id = a.owner_id WHERE o.name = $1 AND a.type = $2"
+		if result.queries[0] != want {
+			t.Errorf("Find() query = %v, want %v", result.queries[0], want)
+		}
+		if args := []driver.Value{"bob", "normal"}; !reflect.DeepEqual(result.args[0], args) {
+			t.Errorf("Find() args = %v, want %v", result.args[0], args)
+		}
+	})
+	t.Run("pluck", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(1)})
+		var ids []int64
+		if err := pg.Raw(query, "bob", "normal").Sort("id", "desc").Limit(10).Pluck("id", &ids); err != nil {
+			t.Fatal(err)
+		}
+		want := `SELECT id FROM (SELECT a.id, a.name FROM app a JOIN owner o ON o.id = a.owner_id WHERE o.name = $1 AND a.type = $2` + "\n" + `) AS "t" ` +
+			`ORDER BY id DESC LIMIT 10`
+		if result.queries[0] != want {
+			t.Errorf("Pluck() query = %v, want %v", result.queries[0], want)
+		}
+	})
+	t.Run("comment", func(t *testing.T) {
+		pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(1)})
+		var count int64
+		if err := pg.Raw("SELECT id FROM app -- all apps").Count(&count); err != nil {
+			t.Fatal(err)
+		}
+		if want := "SELECT COUNT(*) FROM (SELECT id FROM app -- all apps\n) AS \"t\""; result.queries[0] != want {
+			t.Errorf("Count() query = %v, want %v", result.queries[0], want)
+		}
+	})
+	t.Run("arguments", func(t *testing.T) {
+		pg, _ := fakeDb(t, []string{"id"})
+		var apps []fakeApp
+		if err := pg.Raw(query, "bob").Find(&apps); err == nil {
+			t.Error("Find() error = nil, want error")
+		}
+	})
+}
+
+func TestPg_Exec(t *testing.T) {
+	pg, result := fakeDb(t, nil)
+	if _, err := pg.Exec("UPDATE app SET hits = hits + ? WHERE id = ?", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE app SET hits = hits + $1 WHERE id = $2"; result.queries[0] != want {
+		t.Errorf("Exec() query = %v, want %v", result.queries[0], want)
+	}
+}
+
+func TestPg_Exec_literals(t *testing.T) {
+	pg, result := fakeDb(t, nil)
+	if _, err := pg.Exec("UPDATE app SET note = 'why?', \"a?\" = $$ ? $$ /* ? */ WHERE id = ? -- by id?", 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := "UPDATE app SET note = 'why?', \"a?\" = $$ ? $$ /* ? */ WHERE id = $1 -- by id?"; result.queries[0] != want {
+		t.Errorf("Exec() query = %v, want %v", result.queries[0], want)
+	}
+	if args := []driver.Value{int64(1)}; !reflect.DeepEqual(result.args[0], args) {
+		t.Errorf("Exec() args = %v, want %v", result.args[0], args)
+	}
+}
+
+func TestPg_Raw_literals(t *testing.T) {
+	pg, result := fakeDb(t, []string{"id"}, []driver.Value{int64(1)})
+	var ids []int64
+	query := "SELECT id FROM app WHERE note <> E'it\\'s ?' AND type = ? -- type?"
+	if err := pg.Raw(query, "normal").Limit(10).Pluck("id", &ids); err != nil {
+		t.Fatal(err)
+	}
+	want := "SELECT id FROM (SELECT id FROM app WHERE note <> E'it\\'s ?' AND type = $1 -- type?\n) AS \"t\" LIMIT 10"
+	if result.queries[0] != want {
+		t.Errorf("Pluck() query = %v, want %v", result.queries[0], want)
+	}
+}
@@ -0,0 +1,180 @@
+package sql
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//rowMapType is the type of a row found into a map
+var rowMapType = reflect.TypeOf(map[string]interface{}{})
+
+//isRowMap reports whether dest is a *map[string]interface{} or a *[]map[string]interface{}
+func isRowMap(dest interface{}) bool {
+	typ := reflect.TypeOf(dest)
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return false
+	}
+	typ = typ.Elem()
+	return typ == rowMapType || (typ.Kind() == reflect.Slice && typ.Elem() == rowMapType)
+}
+
+//scanMap scans the current row into the map,the values are converted by the database types of the columns:
+//numeric => decimal string,json/jsonb => decoded value (the numbers are json.Number),arrays => []interface{},
+//bytea => []byte,the other texts => string,NULL => nil
+func (m *mapper) scanMap(rows *sql.Rows, dest *map[string]interface{}) error {
+	if m.types == nil {
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			return fmt.Errorf("column types error:%w", err)
+		}
+		m.types = make([]string, len(types))
+		for i, column := range types {
+			m.types[i] = strings.ToUpper(column.DatabaseTypeName())
+		}
+	}
+	values := make([]interface{}, len(m.columns))
+	receivers := make([]interface{}, len(m.columns))
+	for i := range values {
+		receivers[i] = &values[i]
+	}
+	if err := rows.Scan(receivers...); err != nil {
+		return fmt.Errorf("scan record error:%w", err)
+	}
+	row := make(map[string]interface{}, len(m.columns))
+	for i, column := range m.columns {
+		value, err := convertColumn(m.types[i], values[i])
+		if err != nil {
+			return fmt.Errorf("column '%s':%w", column, err)
+		}
+		row[column] = value
+	}
+	*dest = row
+	return nil
+}
+
+//convertColumn converts the value returned by the driver by the database type of the column
+func convertColumn(typeName string, value interface{}) (interface{}, error) {
+	var text string
+	switch v := value.(type) {
+	case []byte:
+		if typeName == "BYTEA" {
+			return append([]byte{}, v...), nil
+		}
+		text = string(v)
+	case string:
+		text = v
+	default:
+		return value, nil
+	}
+	if strings.HasPrefix(typeName, "_") {
+		elemType := strings.TrimPrefix(typeName, "_")
+		return parseArray(text, func(elem string) (interface{}, error) {
+			return convertElem(elemType, elem)
+		})
+	}
+	return convertElem(typeName, text)
+}
+
+//convertElem converts the text of a value (or an array element) by the database type
+func convertElem(typeName, text string) (interface{}, error) {
+	switch typeName {
+	case "JSON", "JSONB":
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader([]byte(text)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("json unmarshal error:%w", err)
+		}
+		return value, nil
+	case "INT2", "INT4", "INT8":
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n, nil
+		}
+	case "FLOAT4", "FLOAT8":
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f, nil
+		}
+	case "BOOL":
+		switch text {
+		case "t", "true":
+			return true, nil
+		case "f", "false":
+			return false, nil
+		}
+	}
+	return text, nil
+}
+
+//parseArray parses the text of a postgres array,eg: {1,NULL,"a \"b\""} or {{1,2},{3,4}}
+func parseArray(text string, elem func(string) (interface{}, error)) ([]interface{}, error) {
+	values, rest, err := parseArrayLevel(text, elem)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("invalid array '%s'", text)
+	}
+	return values, nil
+}
+
+func parseArrayLevel(text string, elem func(string) (interface{}, error)) ([]interface{}, string, error) {
+	if !strings.HasPrefix(text, "{") {
+		return nil, "", fmt.Errorf("invalid array '%s'", text)
+	}
+	s := text[1:]
+	values := []interface{}{}
+	if strings.HasPrefix(s, "}") {
+		return values, s[1:], nil
+	}
+	for {
+		if s == "" {
+			return nil, "", fmt.Errorf("invalid array '%s'", text)
+		}
+		var value interface{}
+		var err error
+		switch s[0] {
+		case '{':
+			value, s, err = parseArrayLevel(s, elem)
+		case '"':
+			var quoted strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				quoted.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, "", fmt.Errorf("invalid array '%s'", text)
+			}
+			value, err = elem(quoted.String())
+			s = s[i+1:]
+		default:
+			end := strings.IndexAny(s, ",}")
+			if end == -1 {
+				return nil, "", fmt.Errorf("invalid array '%s'", text)
+			}
+			if token := s[:end]; token != "NULL" {
+				value, err = elem(token)
+			}
+			s = s[end:]
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		values = append(values, value)
+		switch {
+		case strings.HasPrefix(s, ","):
+			s = s[1:]
+		case strings.HasPrefix(s, "}"):
+			return values, s[1:], nil
+		default:
+			return nil, "", fmt.Errorf("invalid array '%s'", text)
+		}
+	}
+}
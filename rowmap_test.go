@@ -0,0 +1,63 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_Find_map(t *testing.T) {
+	pg, result := fakeDb(t, []string{"id", "amount", "meta", "tags", "scores", "raw"},
+		[]driver.Value{int64(1), []byte("12.50"), []byte(`{"a":1,"b":[true]}`), []byte(`{a,NULL,"b \"c\""}`), []byte("{{1,2},{3,4}}"), []byte{0, 1}},
+		[]driver.Value{int64(2), nil, nil, []byte("{}"), nil, nil},
+	)
+	result.types = []string{"INT8", "NUMERIC", "JSONB", "_TEXT", "_INT4", "BYTEA"}
+	var rows []map[string]interface{}
+	if err := pg.Table("app").Find(&rows); err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]interface{}{
+		{
+			"id":     int64(1),
+			"amount": "12.50",
+			"meta":   map[string]interface{}{"a": json.Number("1"), "b": []interface{}{true}},
+			"tags":   []interface{}{"a", nil, `b "c"`},
+			"scores": []interface{}{[]interface{}{int64(1), int64(2)}, []interface{}{int64(3), int64(4)}},
+			"raw":    []byte{0, 1},
+		},
+		{"id": int64(2), "amount": nil, "meta": nil, "tags": []interface{}{}, "scores": nil, "raw": nil},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Find() = %v, want %v", rows, want)
+	}
+
+	var row map[string]interface{}
+	if err := pg.Table("app").Find(&row); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(row, want[0]) {
+		t.Errorf("Find() = %v, want %v", row, want[0])
+	}
+}
+
+func TestPgTable_Save_map(t *testing.T) {
+	pg, result := fakeDb(t, nil)
+	row := map[string]interface{}{"id": 1}
+	err := pg.Table("app").Save(&row)
+	if want := "save:map destinations are only supported by Find/Each"; err == nil || err.Error() != want {
+		t.Errorf("Save() error = %v, want %s", err, want)
+	}
+	if len(result.queries) != 0 {
+		t.Errorf("Save() queries = %v, want none", result.queries)
+	}
+}
+
+func Test_parseArray(t *testing.T) {
+	elem := func(text string) (interface{}, error) { return text, nil }
+	for _, text := range []string{"", "{", "{a", `{"a}`, "{a}b", "{a}}"} {
+		if _, err := parseArray(text, elem); err == nil {
+			t.Errorf("parseArray(%q) error = nil, want error", text)
+		}
+	}
+}
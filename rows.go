@@ -0,0 +1,231 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+//Rows is the iterator of the result,the rows are scanned one by one,so the result is never loaded into memory at once
+//eg:
+//	rows, err := db.Table("app").Rows()
+//	defer rows.Close()
+//	for rows.Next() {
+//		err = rows.Scan(&app)
+//	}
+//	err = rows.Err()
+type Rows struct {
+	rows   *sql.Rows
+	stmt   *sql.Stmt
+	mapper *mapper
+	label  string
+	err    error
+	//cursor is set in the server-side cursor mode,the rows are fetched batch by batch
+	cursor *serverCursor
+}
+
+//Next prepares the next row for Scan,it returns false at the end of the result or on error
+func (r *Rows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	if r.rows.Next() {
+		if r.cursor != nil {
+			r.cursor.fetched++
+		}
+		return true
+	}
+	if r.cursor == nil || r.cursor.fetched < r.cursor.batch {
+		return false
+	}
+	if err := r.rows.Err(); err != nil {
+		return false
+	}
+	r.rows.Close()
+	rows, err := r.cursor.fetch()
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.rows = rows
+	return r.Next()
+}
+
+//Scan scans the current row into the struct pointer (the columns are mapped by the json tags like Find)
+//or into a *map[string]interface{}
+func (r *Rows) Scan(dest interface{}) error {
+	if err := r.mapper.scan(r.rows, dest); err != nil {
+		r.err = err
+		return err
+	}
+	return nil
+}
+
+//Err returns the error raised while iterating
+func (r *Rows) Err() error {
+	if r.err != nil {
+		return fmt.Errorf("%s:%w", r.label, r.err)
+	}
+	if err := r.rows.Err(); err != nil {
+		return fmt.Errorf("%s:rows error:%w", r.label, err)
+	}
+	return nil
+}
+
+//Close closes the rows and the statement (or the server-side cursor),it is safe to call it more than once
+func (r *Rows) Close() error {
+	//the transaction of the cursor is rolled back if the iteration failed
+	abort := r.err != nil || r.rows.Err() != nil
+	err := r.rows.Close()
+	if r.stmt != nil {
+		if stmtErr := r.stmt.Close(); err == nil {
+			err = stmtErr
+		}
+		r.stmt = nil
+	}
+	if r.cursor != nil {
+		if cursorErr := r.cursor.close(abort); err == nil {
+			err = cursorErr
+		}
+		r.cursor = nil
+	}
+	return err
+}
+
+//Rows runs the query and returns the iterator,the caller must close it
+func (p *PgTable) Rows() (*Rows, error) {
+	return p.open("rows")
+}
+
+//Each scans the rows one by one into dest (a reusable struct pointer) and calls f after each row,
+//it stops at the first error returned by f
+//eg: err := db.Table("app").Each(&app, func() error { return writer.Write(app) })
+func (p *PgTable) Each(dest interface{}, f func() error) error {
+	if _, ok := dest.(*map[string]interface{}); !ok && (reflect.TypeOf(dest).Kind() != reflect.Ptr || reflect.ValueOf(dest).Elem().Kind() != reflect.Struct) {
+		p.clear()
+		return fmt.Errorf("each:dest must be a struct pointer or a *map[string]interface{}")
+	}
+	rows, err := p.open("each")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = rows.Scan(dest); err != nil {
+			return fmt.Errorf("each:%w", err)
+		}
+		if err = f(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+//open builds the SELECT and runs it,label is the prefix of the errors
+func (p *PgTable) open(label string) (*Rows, error) {
+	sql := p.parseSQL(opTypeQuery)
+	defer p.clear()
+	if p.err != nil {
+		return nil, fmt.Errorf("%s:%w", label, p.err)
+	}
+	if p.cursorBatch > 0 {
+		return p.openCursor(label, sql.String())
+	}
+	stmt, err := p.executor().PrepareContext(p.ctx, sql.String())
+	if err != nil {
+		return nil, fmt.Errorf("%s:prepare sql error:%w", label, err)
+	}
+	rows, err := stmt.QueryContext(p.ctx, p.filler...)
+	if err != nil {
+		stmt.Close()
+		return nil, fmt.Errorf("%s:query context:%w", label, err)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		stmt.Close()
+		return nil, fmt.Errorf("%s:%w", label, err)
+	}
+	return &Rows{rows: rows, stmt: stmt, mapper: &mapper{columns: columns}, label: label}, nil
+}
+
+//mapper maps the columns of the result into the struct fields by the json tags
+type mapper struct {
+	columns []string
+	//types are the database types of the columns,they are loaded by the first scan into a map
+	types       []string
+	typ         reflect.Type
+	receiver    []interface{}
+	receiverMap map[string]int
+}
+
+//prepare creates the receivers of the columns for the struct type
+//the receivers are pointers of pointers,so NULL (eg: LAG(...) of the first row) is scanned as nil
+func (m *mapper) prepare(typ reflect.Type) {
+	m.typ = typ
+	m.receiver = make([]interface{}, len(m.columns))
+	m.receiverMap = make(map[string]int)
+	for i, column := range m.columns {
+		var tmp *string
+		m.receiver[i] = &tmp
+		m.receiverMap[column] = i
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		obj := typ.Field(i)
+		receiverIdx, findOK := m.receiverMap[jsonName(obj)]
+		if !findOK {
+			continue
+		}
+		switch obj.Type.String() {
+		case "int", "uint", "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64",
+			"*int", "*uint", "*int8", "*int16", "*int32", "*int64", "*uint8", "*uint16", "*uint32", "*uint64":
+			var intMeta *int64
+			m.receiver[receiverIdx] = &intMeta
+		case "float32", "float64", "*float32", "*float64":
+			var floatMeta *float64
+			m.receiver[receiverIdx] = &floatMeta
+		case "time.Time", "*time.Time":
+			var timeMeta *time.Time
+			m.receiver[receiverIdx] = &timeMeta
+		case "bool", "*bool":
+			var boolMeta *bool
+			m.receiver[receiverIdx] = &boolMeta
+		default:
+			var strMeta *string
+			m.receiver[receiverIdx] = &strMeta
+		}
+	}
+}
+
+//scan scans the current row into the struct pointer
+func (m *mapper) scan(rows *sql.Rows, dest interface{}) error {
+	if row, ok := dest.(*map[string]interface{}); ok {
+		return m.scanMap(rows, row)
+	}
+	typ := reflect.TypeOf(dest)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be a struct pointer")
+	}
+	if m.typ != typ.Elem() {
+		m.prepare(typ.Elem())
+	}
+	if err := rows.Scan(m.receiver...); err != nil {
+		return fmt.Errorf("scan record error:%w", err)
+	}
+	packJson := map[string]interface{}{}
+	for key, idx := range m.receiverMap {
+		packJson[key] = m.receiver[idx]
+	}
+	jsonByte, err := json.Marshal(packJson)
+	if err != nil {
+		return fmt.Errorf("json marshal error:%w", err)
+	}
+	//json null doesn't overwrite the field,so dest is reset for each row
+	reflect.ValueOf(dest).Elem().Set(reflect.Zero(typ.Elem()))
+	if err = json.Unmarshal(jsonByte, dest); err != nil {
+		return fmt.Errorf("json unmarshal error:%w", err)
+	}
+	return nil
+}
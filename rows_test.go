@@ -0,0 +1,247 @@
+package sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+//fakeDriver returns the registered result for any query,it is used to test the scanning without postgres
+type fakeDriver struct{}
+
+type fakeResult struct {
+	columns []string
+	//types are the database types of the columns,they are optional
+	types   []string
+	rows    [][]driver.Value
+	queries []string
+	args    [][]driver.Value
+	closed  int
+	//fetched is the number of the rows returned by FETCH
+	fetched int
+	//prefixed are the results of the queries which start with the keys,they are optional
+	prefixed map[string]*fakeResult
+	//queue are the rows of the next queries,each query takes the first one,the rows are used when it is empty
+	queue [][][]driver.Value
+	//failed are the errors of the queries which start with the keys,they are optional
+	failed map[string]error
+	//commits and rollbacks count the ends of the transactions
+	commits   int
+	rollbacks int
+}
+
+var (
+	fakeResults   = map[string]*fakeResult{}
+	fakeResultsMu sync.Mutex
+)
+
+func init() {
+	sql.Register("sqlxfake", fakeDriver{})
+}
+
+//fakeDb builds a Pg whose queries all return the columns and the rows
+func fakeDb(t *testing.T, columns []string, rows ...[]driver.Value) (*Pg, *fakeResult) {
+	result := &fakeResult{columns: columns, rows: rows}
+	fakeResultsMu.Lock()
+	fakeResults[t.Name()] = result
+	fakeResultsMu.Unlock()
+	db, err := sql.Open("sqlxfake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pg := fakePg()
+	pg.db = db
+	return pg, result
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeResultsMu.Lock()
+	defer fakeResultsMu.Unlock()
+	result, ok := fakeResults[name]
+	if !ok {
+		return nil, errors.New("fake result not found")
+	}
+	return &fakeConn{result: result}, nil
+}
+
+type fakeConn struct {
+	result *fakeResult
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	fakeResultsMu.Lock()
+	defer fakeResultsMu.Unlock()
+	c.result.commits++
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	fakeResultsMu.Lock()
+	defer fakeResultsMu.Unlock()
+	c.result.rollbacks++
+	return nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	s.conn.result.closed++
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := s.record(args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := s.record(args); err != nil {
+		return nil, err
+	}
+	result := s.conn.result
+	var batch int
+	if _, err := fmt.Sscanf(s.query, "FETCH FORWARD %d FROM", &batch); err == nil {
+		start := result.fetched
+		end := start + batch
+		if end > len(result.rows) {
+			end = len(result.rows)
+		}
+		result.fetched = end
+		return &fakeRows{result: &fakeResult{columns: result.columns, types: result.types, rows: result.rows[start:end]}}, nil
+	}
+	fakeResultsMu.Lock()
+	if len(result.queue) != 0 {
+		rows := result.queue[0]
+		result.queue = result.queue[1:]
+		fakeResultsMu.Unlock()
+		return &fakeRows{result: &fakeResult{columns: result.columns, types: result.types, rows: rows}}, nil
+	}
+	fakeResultsMu.Unlock()
+	for prefix, prefixed := range result.prefixed {
+		if strings.HasPrefix(s.query, prefix) {
+			return &fakeRows{result: prefixed}, nil
+		}
+	}
+	return &fakeRows{result: result}, nil
+}
+
+//record records the query and returns the error registered for it
+func (s *fakeStmt) record(args []driver.Value) error {
+	fakeResultsMu.Lock()
+	defer fakeResultsMu.Unlock()
+	s.conn.result.queries = append(s.conn.result.queries, s.query)
+	s.conn.result.args = append(s.conn.result.args, args)
+	for prefix, err := range s.conn.result.failed {
+		if strings.HasPrefix(s.query, prefix) {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeRows struct {
+	result *fakeResult
+	cursor int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(index int) string {
+	if index < len(r.result.types) {
+		return r.result.types[index]
+	}
+	return ""
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.cursor >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.cursor])
+	r.cursor++
+	return nil
+}
+
+type fakeApp struct {
+	Id        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Score     *float64   `json:"score,omitempty"`
+	DeletedAt *time.Time `json:"deleted_date"`
+}
+
+func TestPgTable_Each(t *testing.T) {
+	deleted := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	pg, result := fakeDb(t, []string{"id", "name", "score", "deleted_date"},
+		[]driver.Value{int64(1), "a", 1.5, nil},
+		[]driver.Value{int64(2), nil, nil, deleted},
+		[]driver.Value{int64(3), "c", 2.5, nil},
+	)
+	var app fakeApp
+	var got []fakeApp
+	err := pg.Table("app").Each(&app, func() error {
+		got = append(got, app)
+		if app.Id == 2 {
+			return io.EOF
+		}
+		return nil
+	})
+	if err != io.EOF {
+		t.Fatalf("Each() error = %v, want %v", err, io.EOF)
+	}
+	score := 1.5
+	want := []fakeApp{{Id: 1, Name: "a", Score: &score}, {Id: 2, DeletedAt: &deleted}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Each() = %+v, want %+v", got, want)
+	}
+	if result.closed == 0 {
+		t.Error("Each() didn't close the statement")
+	}
+}
+
+func TestPgTable_Find_slice(t *testing.T) {
+	pg, _ := fakeDb(t, []string{"id", "name"},
+		[]driver.Value{int64(1), "a"},
+		[]driver.Value{int64(2), "b"},
+	)
+	var apps []*fakeApp
+	if err := pg.Table("app").Find(&apps); err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 || apps[0].Name != "a" || apps[1].Id != 2 {
+		t.Errorf("Find() = %+v", apps)
+	}
+}
@@ -0,0 +1,69 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+//SampleMethod is the method of TABLESAMPLE
+type SampleMethod string
+
+const (
+	//SampleSystem samples the pages of the table,it is fast but the rows of a page are picked together
+	SampleSystem SampleMethod = "SYSTEM"
+	//SampleBernoulli samples each row,it scans the whole table
+	SampleBernoulli SampleMethod = "BERNOULLI"
+)
+
+//Sample picks about percent (0-100) of the rows by TABLESAMPLE,the same seed picks the same rows if the table doesn't change
+//eg: err := db.Table("event").Sample(sqlx.SampleBernoulli, 0.1, 42).Where("type=?", "click").Find(&events)
+func (p *PgTable) Sample(method SampleMethod, percent float64, seed ...int64) Query {
+	if method != SampleSystem && method != SampleBernoulli {
+		p.setErr(fmt.Errorf("sample:unsupported method '%s'", method))
+		return p.query
+	}
+	if percent < 0 || percent > 100 {
+		p.setErr(fmt.Errorf("sample:percent must be between 0 and 100"))
+		return p.query
+	}
+	if len(seed) > 1 {
+		p.setErr(fmt.Errorf("sample:only one seed can be set"))
+		return p.query
+	}
+	p.sample = &storage{
+		bucket: " TABLESAMPLE " + string(method) + " (" + strconv.FormatFloat(percent, 'f', -1, 64) + ")",
+	}
+	if len(seed) != 0 {
+		p.sample.bucket += " REPEATABLE (?)"
+		p.sample.argc = []interface{}{seed[0]}
+	}
+	return p.query
+}
+
+//SampleRandom picks n random rows by ORDER BY random() LIMIT n,it is exact but sorts the whole result,
+//use it for small tables or together with Where
+func (p *PgTable) SampleRandom(n int64) Query {
+	if n < 1 {
+		p.setErr(fmt.Errorf("sample:n must be greater than 0"))
+		return p.query
+	}
+	p.sort = []*storage{{storageType: storageTypeSort, bucket: "random()"}}
+	p.limit = n
+	return p.query
+}
+
+//parseSample renders the TABLESAMPLE after the table name,it can only be used by the queries of a table
+func (p *PgTable) parseSample(op opType) string {
+	if p.sample == nil {
+		return ""
+	}
+	switch {
+	case op == opTypeCreate || op == opTypeSave || op == opTypeDelete || op == opTypeSaveInt || op == opTypeSaveDec:
+		p.setErr(fmt.Errorf("sample:only the queries can be sampled"))
+		return ""
+	case p.from != nil || p.raw != nil:
+		p.setErr(fmt.Errorf("sample:a subquery can't be sampled"))
+		return ""
+	}
+	return p.bind(p.sample.bucket, p.sample.argc)
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_Sample(t *testing.T) {
+	pg := fakePg()
+	tests := []struct {
+		name   string
+		table  Table
+		op     opType
+		want   string
+		filler []interface{}
+		err    bool
+	}{
+		{
+			name:   "bernoulli with seed",
+			table:  pg.Table("event").Where("type=?", "click").Sample(SampleBernoulli, 0.5, 42).(*PgQuery).table,
+			op:     opTypeQuery,
+			want:   `SELECT * FROM "event" TABLESAMPLE BERNOULLI (0.5) REPEATABLE ($1) WHERE type=$2`,
+			filler: []interface{}{int64(42), "click"},
+		},
+		{
+			name:   "random",
+			table:  pg.Table("event").Where("type=?", "click").SampleRandom(100).(*PgQuery).table,
+			op:     opTypeQuery,
+			want:   `SELECT * FROM "event" WHERE type=$1 ORDER BY random() LIMIT 100`,
+			filler: []interface{}{"click"},
+		},
+		{
+			name:  "invalid percent",
+			table: pg.Table("event").Sample(SampleSystem, 120).(*PgQuery).table,
+			op:    opTypeQuery,
+			err:   true,
+		},
+		{
+			name:  "delete",
+			table: pg.Table("event").Sample(SampleSystem, 10).(*PgQuery).table,
+			op:    opTypeDelete,
+			err:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, filler, err := parse(tt.table, tt.op)
+			if (err != nil) != tt.err {
+				t.Fatalf("parseSQL() error = %v, want error %v", err, tt.err)
+			}
+			if tt.err {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseSQL() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(filler, tt.filler) {
+				t.Errorf("parseSQL() filler = %v, want %v", filler, tt.filler)
+			}
+		})
+	}
+}
+
+func TestPgTable_Sample_count(t *testing.T) {
+	pg, result := fakeDb(t, []string{"count"}, []driver.Value{int64(42)})
+	var count int64
+	if err := pg.Table("event").Where("type=?", "click").Sample(SampleSystem, 10, 7).Count(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 42 {
+		t.Errorf("Count() = %v, want 42", count)
+	}
+	if want := `SELECT COUNT(*) FROM "event" TABLESAMPLE SYSTEM (10) REPEATABLE ($1) WHERE type=$2`; result.queries[0] != want {
+		t.Errorf("Count() query = %v, want %v", result.queries[0], want)
+	}
+	if args := []driver.Value{int64(7), "click"}; !reflect.DeepEqual(result.args[0], args) {
+		t.Errorf("Count() args = %v, want %v", result.args[0], args)
+	}
+}
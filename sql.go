@@ -8,23 +8,78 @@ import (
 type SQL interface {
 	initialize() SQL
 	Conn() *sql.DB
+	Transaction(f func(tx SQL) error) error
+	SetStrict(strict bool) SQL
+	SetSchema(schema string) SQL
+	SetCursorSecret(secret []byte) SQL
 	Table(tableName string) Table
+	From(subquery interface{}, alias string) Table
+	Raw(query string, argc ...interface{}) Query
+	Exec(query string, argc ...interface{}) (sql.Result, error)
 	clear()
 }
 
 type Table interface {
-	Select(fields string) Table
+	Schema(schema string) Table
+	Select(fields ...interface{}) Table
+	Distinct() Table
+	DistinctOn(columns ...interface{}) Table
+	Window(name string, spec *WindowSpec) Table
+	From(subquery interface{}, alias string) Table
+	With(name string, body interface{}) Table
+	WithRecursive(name string, anchor, recursive interface{}) Table
+	Returning(fields ...interface{}) Table
 	Where(where string, argc ...interface{}) Table
 	WhereOr(where string, argc ...interface{}) Table
-	Sort(filed string, sortBy string) Query
+	Sort(filed interface{}, sortBy string) Query
+	SortNulls(filed interface{}, order SortOrder, nulls Nulls) Query
+	SortExpr(expr interface{}, order SortOrder, nulls Nulls, argc ...interface{}) Query
+	Union(query Query) Query
+	UnionAll(query Query) Query
+	Intersect(query Query) Query
+	Except(query Query) Query
+	ForUpdate(of ...string) Query
+	ForNoKeyUpdate(of ...string) Query
+	ForShare(of ...string) Query
+	ForKeyShare(of ...string) Query
+	SkipLocked() Query
+	NoWait() Query
+	Sample(method SampleMethod, percent float64, seed ...int64) Query
+	SampleRandom(n int64) Query
 	Offset(offset int64) Query
 	Limit(limit int64) Query
-	Group(group string) Query
+	Group(group ...interface{}) Query
+	Having(having string, argc ...interface{}) Query
+	HavingOr(having string, argc ...interface{}) Query
 	Find(dest interface{}) error
+	FindMap(dest interface{}) error
+	First(dest interface{}) error
+	Last(dest interface{}) error
+	Take(dest interface{}) error
+	FindByPK(dest interface{}, id interface{}) error
+	Pluck(column interface{}, dest interface{}) error
+	Exists() (bool, error)
+	Cursor(batch int64) Query
+	Rows() (*Rows, error)
+	Each(dest interface{}, f func() error) error
 	Count(count *int64) error
-	Sum(sum *int64) error
-	Avg(avg *int64) error
+	EstimateCount(count *int64) error
+	SmartCount(threshold int64, count *int64) error
+	Sum(sum interface{}) error
+	Avg(avg interface{}) error
+	Max(max interface{}) error
+	Min(min interface{}) error
+	CountDistinct(count interface{}) error
+	Aggregate(dest interface{}) error
+	Percentile(column string, fractions ...float64) ([]float64, error)
+	Stddev(column string) (float64, error)
+	Histogram(column string, min, max float64, count int) ([]Bucket, error)
+	TimeSeries(column, unit string, config ...SeriesConfig) ([]TimeBucket, error)
+	Paginate(page, size int64, dest interface{}) (*Page, error)
+	PaginateConcurrently(page, size int64, dest interface{}) (*Page, error)
+	Keyset(cursor string, size int64, dest interface{}) (*KeysetPage, error)
 	Update(dest interface{}) error
+	FindInBatches(size int64, dest interface{}, f func(batch *Batch) error, config ...BatchConfig) error
 	Save(dest interface{}) error
 	Delete() error
 	SetInc(field string) error // feature:field value + 1
@@ -33,12 +88,51 @@ type Table interface {
 }
 
 type Query interface {
-	Sort(filed string, sortBy string) Query
+	Sort(filed interface{}, sortBy string) Query
+	SortNulls(filed interface{}, order SortOrder, nulls Nulls) Query
+	SortExpr(expr interface{}, order SortOrder, nulls Nulls, argc ...interface{}) Query
+	Union(query Query) Query
+	UnionAll(query Query) Query
+	Intersect(query Query) Query
+	Except(query Query) Query
+	ForUpdate(of ...string) Query
+	ForNoKeyUpdate(of ...string) Query
+	ForShare(of ...string) Query
+	ForKeyShare(of ...string) Query
+	SkipLocked() Query
+	NoWait() Query
+	Sample(method SampleMethod, percent float64, seed ...int64) Query
+	SampleRandom(n int64) Query
 	Offset(offset int64) Query
 	Limit(limit int64) Query
-	Group(group string) Query
+	Group(group ...interface{}) Query
+	Having(having string, argc ...interface{}) Query
+	HavingOr(having string, argc ...interface{}) Query
 	Find(dest interface{}) error
+	FindMap(dest interface{}) error
+	First(dest interface{}) error
+	Last(dest interface{}) error
+	Take(dest interface{}) error
+	FindByPK(dest interface{}, id interface{}) error
+	Pluck(column interface{}, dest interface{}) error
+	Exists() (bool, error)
+	Cursor(batch int64) Query
+	Rows() (*Rows, error)
+	Each(dest interface{}, f func() error) error
 	Count(count *int64) error
-	Sum(sum *int64) error
-	Avg(avg *int64) error
+	EstimateCount(count *int64) error
+	SmartCount(threshold int64, count *int64) error
+	Sum(sum interface{}) error
+	Avg(avg interface{}) error
+	Max(max interface{}) error
+	Min(min interface{}) error
+	CountDistinct(count interface{}) error
+	Aggregate(dest interface{}) error
+	Percentile(column string, fractions ...float64) ([]float64, error)
+	Stddev(column string) (float64, error)
+	Histogram(column string, min, max float64, count int) ([]Bucket, error)
+	TimeSeries(column, unit string, config ...SeriesConfig) ([]TimeBucket, error)
+	Paginate(page, size int64, dest interface{}) (*Page, error)
+	PaginateConcurrently(page, size int64, dest interface{}) (*Page, error)
+	Keyset(cursor string, size int64, dest interface{}) (*KeysetPage, error)
 }
@@ -0,0 +1,156 @@
+package sql
+
+import (
+	"fmt"
+	"math"
+)
+
+//Bucket is a bucket of Histogram,the values in [Lower,Upper) are counted
+type Bucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int64   `json:"count"`
+}
+
+//PercentileOf is percentile_cont(fraction) WITHIN GROUP (ORDER BY column),fraction is between 0 and 1
+func PercentileOf(column string, fraction float64) *AggregateFunc {
+	return &AggregateFunc{fn: "percentile_cont(?::float8) WITHIN GROUP (ORDER BY ", column: column, argc: []interface{}{fraction}}
+}
+
+//StddevOf is stddev_samp(column)
+func StddevOf(column string) *AggregateFunc {
+	return &AggregateFunc{fn: "stddev_samp(", column: column}
+}
+
+//Filter makes a conditional aggregate by FILTER (WHERE ...),it returns a copy so the aggregate can be reused
+//eg: db.Table("order").Select(As(sqlx.CountOf("*").Filter("status = ?", "paid"), "paid"), As(sqlx.CountOf("*"), "total"))
+func (f *AggregateFunc) Filter(where string, argc ...interface{}) *AggregateFunc {
+	filtered := *f
+	filtered.filter = &storage{bucket: where, argc: argc}
+	return &filtered
+}
+
+//Percentile finds the continuous percentiles of the column in one round-trip,fraction is between 0 and 1,
+//NaN is returned for an empty set
+//eg: p, err := db.Table("request").Where("path=?", path).Percentile("duration", 0.5, 0.95, 0.99)
+func (p *PgTable) Percentile(column string, fractions ...float64) ([]float64, error) {
+	if len(fractions) == 0 {
+		p.clear()
+		return nil, fmt.Errorf("percentile:please set the fractions")
+	}
+	p.fields = nil
+	for _, fraction := range fractions {
+		if fraction < 0 || fraction > 1 {
+			p.clear()
+			return nil, fmt.Errorf("percentile:fraction %v must be between 0 and 1", fraction)
+		}
+		p.fields = append(p.fields, p.parseAggregateFunc(PercentileOf(column, fraction)))
+	}
+	return p.floats("percentile", len(fractions))
+}
+
+//Stddev finds the sample standard deviation of the column,NaN is returned if there are less than two rows
+func (p *PgTable) Stddev(column string) (float64, error) {
+	p.fields = []*storage{p.parseAggregateFunc(StddevOf(column))}
+	values, err := p.floats("stddev", 1)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+//floats runs the query of the selected float columns and scans the first row,NULL is scanned as NaN
+func (p *PgTable) floats(label string, n int) ([]float64, error) {
+	p.group = nil
+	p.having = nil
+	p.sort = nil
+	p.offset, p.limit = 0, 0
+	p.lock = nil
+	rows, err := p.open(label)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	receivers := make([]interface{}, n)
+	for i := range receivers {
+		receivers[i] = new(*float64)
+	}
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.NaN()
+	}
+	if rows.Next() {
+		if err = rows.rows.Scan(receivers...); err != nil {
+			return nil, fmt.Errorf("%s:scan record error:%w", label, err)
+		}
+		for i, receiver := range receivers {
+			if value := *receiver.(**float64); value != nil {
+				values[i] = *value
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+//Histogram counts the values of the column in count buckets of equal width between min and max by width_bucket,
+//all the buckets are returned even if they are empty,the values out of the range are counted in the extra buckets
+//(-Inf,min) and [max,+Inf) which are only returned if they are not empty
+//eg: buckets, err := db.Table("request").Histogram("duration", 0, 1000, 10)
+func (p *PgTable) Histogram(column string, min, max float64, count int) ([]Bucket, error) {
+	if count < 1 || min >= max {
+		p.clear()
+		return nil, fmt.Errorf("histogram:count must be greater than 0 and min must be less than max")
+	}
+	p.fields = []*storage{
+		{bucket: "width_bucket(" + p.parseIdent(column) + ", ?::float8, ?::float8, ?::int) AS \"bucket\"", argc: []interface{}{min, max, count}},
+		{bucket: "COUNT(*) AS \"count\""},
+	}
+	p.group = []*storage{{bucket: "1"}}
+	p.having = nil
+	p.sort = []*storage{{storageType: storageTypeSort, bucket: "1 " + string(Asc)}}
+	p.offset, p.limit = 0, 0
+	p.lock = nil
+	rows, err := p.open("histogram")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	width := (max - min) / float64(count)
+	buckets := make([]Bucket, count)
+	for i := range buckets {
+		buckets[i].Lower = min + float64(i)*width
+		buckets[i].Upper = min + float64(i+1)*width
+	}
+	buckets[count-1].Upper = max
+	var under, over *Bucket
+	for rows.Next() {
+		var index *int64
+		var n int64
+		if err = rows.rows.Scan(&index, &n); err != nil {
+			return nil, fmt.Errorf("histogram:scan record error:%w", err)
+		}
+		switch {
+		case index == nil:
+			//NULL values are not counted
+		case *index < 1:
+			under = &Bucket{Lower: math.Inf(-1), Upper: min, Count: n}
+		case *index > int64(count):
+			over = &Bucket{Lower: max, Upper: math.Inf(1), Count: n}
+		default:
+			buckets[*index-1].Count = n
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if under != nil {
+		buckets = append([]Bucket{*under}, buckets...)
+	}
+	if over != nil {
+		buckets = append(buckets, *over)
+	}
+	return buckets, nil
+}
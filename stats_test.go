@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestAggregateFunc_Filter(t *testing.T) {
+	pg := fakePg()
+	count := CountOf("*")
+	table := pg.Table("order").Where("amount > ?", 10).
+		Select(As(count.Filter("status = ?", "paid"), "paid"), As(count, "total"), As(PercentileOf("amount", 0.9), "p90"))
+	got, filler, err := parse(table, opTypeQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `SELECT COUNT(*) FILTER (WHERE status = $1) AS "paid",COUNT(*) AS "total",` +
+		`percentile_cont($2::float8) WITHIN GROUP (ORDER BY amount) AS "p90" FROM "order" WHERE amount > $3`
+	if got != want {
+		t.Errorf("parseSQL() = %v, want %v", got, want)
+	}
+	if wantFiller := []interface{}{"paid", 0.9, 10}; !reflect.DeepEqual(filler, wantFiller) {
+		t.Errorf("parseSQL() filler = %v, want %v", filler, wantFiller)
+	}
+}
+
+func TestPgTable_Percentile(t *testing.T) {
+	pg, result := fakeDb(t, []string{"p50", "p99"}, []driver.Value{12.5, nil})
+	got, err := pg.Table("request").Percentile("duration", 0.5, 0.99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != 12.5 || !math.IsNaN(got[1]) {
+		t.Errorf("Percentile() = %v", got)
+	}
+	want := `SELECT percentile_cont($1::float8) WITHIN GROUP (ORDER BY duration),` +
+		`percentile_cont($2::float8) WITHIN GROUP (ORDER BY duration) FROM "request"`
+	if result.queries[0] != want {
+		t.Errorf("Percentile() query = %v, want %v", result.queries[0], want)
+	}
+}
+
+func TestPgTable_Stddev_reset(t *testing.T) {
+	tests := []struct {
+		name  string
+		table func(pg *Pg) Query
+	}{
+		{name: "sort", table: func(pg *Pg) Query { return pg.Table("request").Sort("created", "desc") }},
+		{name: "offset", table: func(pg *Pg) Query { return pg.Table("request").Offset(1).Limit(10) }},
+		{name: "lock", table: func(pg *Pg) Query { return pg.Table("request").ForUpdate() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, result := fakeDb(t, []string{"stddev"}, []driver.Value{1.5})
+			got, err := tt.table(pg).Stddev("duration")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != 1.5 {
+				t.Errorf("Stddev() = %v, want 1.5", got)
+			}
+			if want := `SELECT stddev_samp(duration) FROM "request"`; result.queries[0] != want {
+				t.Errorf("Stddev() query = %v, want %v", result.queries[0], want)
+			}
+		})
+	}
+}
+
+func TestPgTable_Histogram(t *testing.T) {
+	pg, result := fakeDb(t, []string{"bucket", "count"},
+		[]driver.Value{int64(0), int64(1)},
+		[]driver.Value{int64(2), int64(5)},
+		[]driver.Value{nil, int64(7)},
+	)
+	got, err := pg.Table("request").Where("path=?", "/").Histogram("duration", 0, 100, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Bucket{
+		{Lower: math.Inf(-1), Upper: 0, Count: 1},
+		{Lower: 0, Upper: 50},
+		{Lower: 50, Upper: 100, Count: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Histogram() = %+v, want %+v", got, want)
+	}
+	query := `SELECT width_bucket(duration, $1::float8, $2::float8, $3::int) AS "bucket",COUNT(*) AS "count" ` +
+		`FROM "request" WHERE path=$4 GROUP BY 1 ORDER BY 1 ASC`
+	if result.queries[0] != query {
+		t.Errorf("Histogram() query = %v, want %v", result.queries[0], query)
+	}
+}
@@ -0,0 +1,126 @@
+package sql
+
+import (
+	"fmt"
+	"time"
+)
+
+//seriesIntervals are the units of date_trunc supported by TimeSeries and the matching intervals of generate_series
+var seriesIntervals = map[string]string{
+	"second":  "1 second",
+	"minute":  "1 minute",
+	"hour":    "1 hour",
+	"day":     "1 day",
+	"week":    "1 week",
+	"month":   "1 month",
+	"quarter": "3 months",
+	"year":    "1 year",
+}
+
+//TimeBucket is a row of TimeSeries
+type TimeBucket struct {
+	Bucket time.Time `json:"bucket"`
+	Value  float64   `json:"value"`
+}
+
+//SeriesConfig is the optional config of TimeSeries
+type SeriesConfig struct {
+	//Value is the aggregate of each bucket,eg: sqlx.SumOf("amount"),it is COUNT(*) if nil
+	Value interface{}
+	//Location is the time zone of the buckets,eg: the day buckets start at the midnight of the location,it is UTC if nil
+	Location *time.Location
+	//Fill adds the zero rows of the empty buckets between From and To
+	Fill bool
+	From time.Time
+	To   time.Time
+}
+
+//TimeSeries groups the rows by date_trunc(unit, column) in the time zone of the config,the column should be a timestamptz,
+//unit is one of second,minute,hour,day,week,month,quarter,year,the buckets are ordered by time,a NULL value is scanned as 0
+//eg: series, err := db.Table("order").Where("status=?", "paid").TimeSeries("created_date", "hour", sqlx.SeriesConfig{
+//		Value: sqlx.SumOf("amount"), Location: shanghai, Fill: true, From: from, To: to,
+//	})
+func (p *PgTable) TimeSeries(column, unit string, config ...SeriesConfig) ([]TimeBucket, error) {
+	defer p.clear()
+	var conf SeriesConfig
+	if len(config) != 0 {
+		conf = config[0]
+	}
+	interval, ok := seriesIntervals[unit]
+	if !ok {
+		return nil, fmt.Errorf("time series:unsupported unit '%s'", unit)
+	}
+	location := conf.Location
+	if location == nil {
+		location = time.UTC
+	}
+	zone := location.String()
+	if zone == "Local" {
+		return nil, fmt.Errorf("time series:please use a named location instead of time.Local")
+	}
+	if conf.Fill && (conf.From.IsZero() || conf.To.IsZero() || conf.To.Before(conf.From)) {
+		return nil, fmt.Errorf("time series:From and To must be set to fill the gaps")
+	}
+	value := conf.Value
+	if value == nil {
+		value = CountOf("*")
+	}
+	values := p.parseColumns([]interface{}{value})
+	if len(values) != 1 {
+		return nil, fmt.Errorf("time series:invalid value %v", value)
+	}
+	values[0].bucket += ` AS "value"`
+	p.fields = []*storage{
+		{bucket: "date_trunc('" + unit + "', " + p.parseIdent(column) + ` AT TIME ZONE ?) AS "bucket"`, argc: []interface{}{zone}},
+		values[0],
+	}
+	p.group = []*storage{{bucket: "1"}}
+	p.sort = nil
+	p.offset, p.limit = 0, 0
+
+	//the inner query buckets the local time,the outer one converts the buckets back to timestamptz
+	series := p.builder(&meta{ctx: p.ctx})
+	if conf.Fill {
+		series.fields = []*storage{
+			{bucket: `"s"."bucket" AT TIME ZONE ? AS "bucket"`, argc: []interface{}{zone}},
+			{bucket: `COALESCE("t"."value", 0) AS "value"`},
+		}
+		series.from = &storage{
+			bucket: "generate_series(date_trunc('" + unit + "', ?::timestamptz AT TIME ZONE ?), date_trunc('" + unit +
+				`', ?::timestamptz AT TIME ZONE ?), '` + interval + `'::interval) AS "s"("bucket") LEFT JOIN ? AS "t" ON "t"."bucket" = "s"."bucket"`,
+			argc: []interface{}{conf.From, zone, conf.To, zone, p},
+		}
+	} else {
+		series.fields = []*storage{
+			{bucket: `"t"."bucket" AT TIME ZONE ? AS "bucket"`, argc: []interface{}{zone}},
+			{bucket: `"t"."value"`},
+		}
+		series.from = &storage{bucket: `? AS "t"`, argc: []interface{}{p}}
+	}
+	series.sort = []*storage{{storageType: storageTypeSort, bucket: "1 " + string(Asc)}}
+	if p.err != nil {
+		series.setErr(p.err)
+	}
+	rows, err := series.open("time series")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var buckets []TimeBucket
+	for rows.Next() {
+		var bucket time.Time
+		var value *float64
+		if err = rows.rows.Scan(&bucket, &value); err != nil {
+			return nil, fmt.Errorf("time series:scan record error:%w", err)
+		}
+		row := TimeBucket{Bucket: bucket.In(location)}
+		if value != nil {
+			row.Value = *value
+		}
+		buckets = append(buckets, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPgTable_TimeSeries(t *testing.T) {
+	shanghai := time.FixedZone("Asia/Shanghai", 8*3600)
+	first := time.Date(2022, 1, 1, 16, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	from, to := first, second.Add(time.Hour)
+	tests := []struct {
+		name   string
+		config SeriesConfig
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "count",
+			config: SeriesConfig{},
+			query: `SELECT "t"."bucket" AT TIME ZONE $3 AS "bucket","t"."value" FROM (SELECT date_trunc('day', created_date AT TIME ZONE $1) AS "bucket",` +
+				`COUNT(*) AS "value" FROM "order" WHERE status=$2 GROUP BY 1) AS "t" ORDER BY 1 ASC`,
+			args: []driver.Value{"UTC", "paid", "UTC"},
+		},
+		{
+			name:   "fill",
+			config: SeriesConfig{Value: SumOf("amount"), Location: shanghai, Fill: true, From: from, To: to},
+			query: `SELECT "s"."bucket" AT TIME ZONE $7 AS "bucket",COALESCE("t"."value", 0) AS "value" FROM ` +
+				`generate_series(date_trunc('day', $1::timestamptz AT TIME ZONE $2), date_trunc('day', $3::timestamptz AT TIME ZONE $4), '1 day'::interval) AS "s"("bucket") ` +
+				`LEFT JOIN (SELECT date_trunc('day', created_date AT TIME ZONE $5) AS "bucket",SUM(amount) AS "value" FROM "order" WHERE status=$6 GROUP BY 1) AS "t" ` +
+				`ON "t"."bucket" = "s"."bucket" ORDER BY 1 ASC`,
+			args: []driver.Value{from, "Asia/Shanghai", to, "Asia/Shanghai", "Asia/Shanghai", "paid", "Asia/Shanghai"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, result := fakeDb(t, []string{"bucket", "value"},
+				[]driver.Value{first, 1.5},
+				[]driver.Value{second, nil},
+			)
+			got, err := pg.Table("order").Where("status=?", "paid").TimeSeries("created_date", "day", tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			location := tt.config.Location
+			if location == nil {
+				location = time.UTC
+			}
+			want := []TimeBucket{{Bucket: first.In(location), Value: 1.5}, {Bucket: second.In(location)}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TimeSeries() = %+v, want %+v", got, want)
+			}
+			if result.queries[0] != tt.query {
+				t.Errorf("TimeSeries() query = %v, want %v", result.queries[0], tt.query)
+			}
+			if !reflect.DeepEqual(result.args[0], tt.args) {
+				t.Errorf("TimeSeries() args = %v, want %v", result.args[0], tt.args)
+			}
+		})
+	}
+	pg := fakePg()
+	if _, err := pg.Table("order").TimeSeries("created_date", "fortnight"); err == nil {
+		t.Error("TimeSeries() error = nil, want error")
+	}
+	if _, err := pg.Table("order").TimeSeries("created_date", "day", SeriesConfig{Fill: true}); err == nil {
+		t.Error("TimeSeries() error = nil, want error")
+	}
+}
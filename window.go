@@ -0,0 +1,250 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+//frame bounds of the window frame clause
+const (
+	UnboundedPreceding = "UNBOUNDED PRECEDING"
+	UnboundedFollowing = "UNBOUNDED FOLLOWING"
+	CurrentRow         = "CURRENT ROW"
+)
+
+//Preceding is the frame bound "n PRECEDING"
+func Preceding(n int64) string {
+	return strconv.FormatInt(n, 10) + " PRECEDING"
+}
+
+//Following is the frame bound "n FOLLOWING"
+func Following(n int64) string {
+	return strconv.FormatInt(n, 10) + " FOLLOWING"
+}
+
+//WindowSpec is the window definition used by OVER (...) and WINDOW name AS (...)
+type WindowSpec struct {
+	base      string
+	partition []interface{}
+	order     []*windowOrder
+	frame     string
+	err       error
+}
+
+type windowOrder struct {
+	column string
+	order  SortOrder
+	nulls  Nulls
+}
+
+//NewWindow creates a window definition,base is the name of a named window to extend (optional)
+//eg: NewWindow().PartitionBy("type").OrderBy("created_date", sqlx.Desc).Rows(sqlx.UnboundedPreceding, sqlx.CurrentRow)
+func NewWindow(base ...string) *WindowSpec {
+	w := &WindowSpec{}
+	if len(base) != 0 {
+		w.base = base[0]
+	}
+	return w
+}
+
+//PartitionBy sets the PARTITION BY columns,each one can be a string,an Ident or a Raw expression
+func (w *WindowSpec) PartitionBy(columns ...interface{}) *WindowSpec {
+	w.partition = append(w.partition, columns...)
+	return w
+}
+
+//OrderBy appends an ORDER BY item of the window,the column is always validated like Sort
+func (w *WindowSpec) OrderBy(column string, order SortOrder, nulls ...Nulls) *WindowSpec {
+	item := &windowOrder{column: column, order: order}
+	if len(nulls) != 0 {
+		item.nulls = nulls[0]
+	}
+	w.order = append(w.order, item)
+	return w
+}
+
+//Rows sets the frame clause: ROWS BETWEEN start AND end
+func (w *WindowSpec) Rows(start, end string) *WindowSpec {
+	return w.setFrame("ROWS", start, end)
+}
+
+//Range sets the frame clause: RANGE BETWEEN start AND end
+func (w *WindowSpec) Range(start, end string) *WindowSpec {
+	return w.setFrame("RANGE", start, end)
+}
+
+//Groups sets the frame clause: GROUPS BETWEEN start AND end
+func (w *WindowSpec) Groups(start, end string) *WindowSpec {
+	return w.setFrame("GROUPS", start, end)
+}
+
+func (w *WindowSpec) setFrame(mode, start, end string) *WindowSpec {
+	for _, bound := range []string{start, end} {
+		if !isFrameBound(bound) {
+			w.err = fmt.Errorf("window:invalid frame bound '%s'", bound)
+			return w
+		}
+	}
+	w.frame = mode + " BETWEEN " + start + " AND " + end
+	return w
+}
+
+//isFrameBound checks the bound is one of the constants or created by Preceding/Following
+func isFrameBound(bound string) bool {
+	switch bound {
+	case UnboundedPreceding, UnboundedFollowing, CurrentRow:
+		return true
+	}
+	for _, suffix := range []string{" PRECEDING", " FOLLOWING"} {
+		if len(bound) > len(suffix) && bound[len(bound)-len(suffix):] == suffix {
+			_, err := strconv.ParseInt(bound[:len(bound)-len(suffix)], 10, 64)
+			return err == nil
+		}
+	}
+	return false
+}
+
+//WindowFunc is a window function call,use As(...) to name the result column
+//eg: Select("id", As(sqlx.RowNumber().Over(sqlx.NewWindow().PartitionBy("type").OrderBy("id", sqlx.Asc)), "rn"))
+type WindowFunc struct {
+	expr   Raw
+	spec   *WindowSpec
+	window string
+}
+
+//WindowFn creates a window function from a sql expression,eg: WindowFn("SUM(amount)")
+func WindowFn(expr Raw) *WindowFunc {
+	return &WindowFunc{expr: expr}
+}
+
+//RowNumber is ROW_NUMBER()
+func RowNumber() *WindowFunc {
+	return WindowFn("ROW_NUMBER()")
+}
+
+//Rank is RANK()
+func Rank() *WindowFunc {
+	return WindowFn("RANK()")
+}
+
+//DenseRank is DENSE_RANK()
+func DenseRank() *WindowFunc {
+	return WindowFn("DENSE_RANK()")
+}
+
+//Over sets the window definition of the function
+func (f *WindowFunc) Over(spec *WindowSpec) *WindowFunc {
+	f.spec = spec
+	f.window = ""
+	return f
+}
+
+//OverWindow uses a named window defined by Table.Window(name, spec)
+func (f *WindowFunc) OverWindow(name string) *WindowFunc {
+	f.window = name
+	f.spec = nil
+	return f
+}
+
+//Window defines a named window: WINDOW name AS (...),it can be used by WindowFunc.OverWindow(name)
+func (p *PgTable) Window(name string, spec *WindowSpec) Table {
+	p.windows = append(p.windows, &namedWindow{name: name, spec: spec})
+	return p
+}
+
+type namedWindow struct {
+	name string
+	spec *WindowSpec
+}
+
+//parseWindowFunc renders the window function
+func (p *PgTable) parseWindowFunc(f *WindowFunc) string {
+	if f.window != "" {
+		return string(f.expr) + " OVER " + p.parseWindowName(f.window)
+	}
+	if f.spec == nil {
+		return string(f.expr) + " OVER ()"
+	}
+	return string(f.expr) + " OVER (" + p.parseWindowSpec(f.spec) + ")"
+}
+
+func (p *PgTable) parseWindowName(name string) string {
+	parts, err := splitIdent(name)
+	if err != nil || len(parts) != 1 || parts[0] == "*" {
+		p.setErr(fmt.Errorf("window:invalid name '%s'", name))
+		return ""
+	}
+	return quotePart(parts[0])
+}
+
+func (p *PgTable) parseWindowSpec(w *WindowSpec) string {
+	if w.err != nil {
+		p.setErr(w.err)
+	}
+	var items []string
+	if w.base != "" {
+		items = append(items, p.parseWindowName(w.base))
+	}
+	if len(w.partition) != 0 {
+		items = append(items, "PARTITION BY "+p.parseStorages(p.parseColumns(w.partition)))
+	}
+	if len(w.order) != 0 {
+		var order bytes.Buffer
+		order.WriteString("ORDER BY ")
+		for i, item := range w.order {
+			if i != 0 {
+				order.WriteString(", ")
+			}
+			switch item.order {
+			case Asc, Desc, "":
+			default:
+				p.setErr(fmt.Errorf("window:invalid sort order '%s'", item.order))
+			}
+			switch item.nulls {
+			case NullsDefault, NullsFirst, NullsLast:
+			default:
+				p.setErr(fmt.Errorf("window:invalid nulls order '%s'", item.nulls))
+			}
+			order.WriteString(p.checkIdent(item.column))
+			if item.order != "" {
+				order.WriteString(" ")
+				order.WriteString(string(item.order))
+			}
+			if item.nulls != NullsDefault {
+				order.WriteString(" ")
+				order.WriteString(string(item.nulls))
+			}
+		}
+		items = append(items, order.String())
+	}
+	if w.frame != "" {
+		items = append(items, w.frame)
+	}
+	var cond bytes.Buffer
+	for i, item := range items {
+		if i != 0 {
+			cond.WriteString(" ")
+		}
+		cond.WriteString(item)
+	}
+	return cond.String()
+}
+
+func (p *PgTable) parseWindows() string {
+	if len(p.meta.windows) == 0 {
+		return ""
+	}
+	var cond bytes.Buffer
+	cond.WriteString(" WINDOW ")
+	for i, w := range p.meta.windows {
+		if i != 0 {
+			cond.WriteString(", ")
+		}
+		cond.WriteString(p.parseWindowName(w.name))
+		cond.WriteString(" AS (")
+		cond.WriteString(p.parseWindowSpec(w.spec))
+		cond.WriteString(")")
+	}
+	return cond.String()
+}
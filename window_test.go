@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestPgTable_Window(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(pg SQL) Table
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "row number",
+			build: func(pg SQL) Table {
+				return pg.Table("app").Select("id", As(RowNumber().Over(NewWindow().PartitionBy("type").OrderBy("id", Desc)), "rn"))
+			},
+			want: `SELECT id,ROW_NUMBER() OVER (PARTITION BY type ORDER BY id DESC) AS "rn" FROM "app"`,
+		},
+		{
+			name: "running total with frame",
+			build: func(pg SQL) Table {
+				w := NewWindow().PartitionBy("user_id").OrderBy("created_date", Asc).Rows(UnboundedPreceding, CurrentRow)
+				return pg.Table("payment").Select("id", As(WindowFn("SUM(amount)").Over(w), "total"))
+			},
+			want: `SELECT id,SUM(amount) OVER (PARTITION BY user_id ORDER BY created_date ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS "total" FROM "payment"`,
+		},
+		{
+			name: "named window",
+			build: func(pg SQL) Table {
+				return pg.Table("payment").
+					Select("id", As(Rank().OverWindow("w"), "rank"), As(WindowFn("AVG(amount)").Over(NewWindow("w").Range(Preceding(3), Following(3))), "avg")).
+					Where("amount>?", 0).
+					Window("w", NewWindow().PartitionBy("user_id").OrderBy("amount", Desc, NullsLast))
+			},
+			want: `SELECT id,RANK() OVER "w" AS "rank",AVG(amount) OVER ("w" RANGE BETWEEN 3 PRECEDING AND 3 FOLLOWING) AS "avg" ` +
+				`FROM "payment" WHERE amount>$1 WINDOW "w" AS (PARTITION BY user_id ORDER BY amount DESC NULLS LAST)`,
+		},
+		{
+			name: "invalid frame bound",
+			build: func(pg SQL) Table {
+				return pg.Table("payment").Select(As(RowNumber().Over(NewWindow().Rows("1; DROP TABLE app", CurrentRow)), "rn"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid order column",
+			build: func(pg SQL) Table {
+				return pg.Table("payment").Select(As(RowNumber().Over(NewWindow().OrderBy("id) FROM app --", Asc)), "rn"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parse(tt.build(fakePg()), opTypeQuery)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseSQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPgTable_Window_find(t *testing.T) {
+	pg, result := fakeDb(t, []string{"id", "rn"}, []driver.Value{int64(7), int64(1)}, []driver.Value{int64(8), int64(2)})
+	var rows []struct {
+		Id int64 `json:"id"`
+		Rn int64 `json:"rn"`
+	}
+	err := pg.Table("app").Select("id", As(RowNumber().Over(NewWindow().OrderBy("id", Asc)), "rn")).Find(&rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Id != 7 || rows[0].Rn != 1 || rows[1].Id != 8 || rows[1].Rn != 2 {
+		t.Errorf("Find() = %+v", rows)
+	}
+	if want := `SELECT id,ROW_NUMBER() OVER (ORDER BY id ASC) AS "rn" FROM "app"`; result.queries[0] != want {
+		t.Errorf("Find() query = %v, want %v", result.queries[0], want)
+	}
+}
+
+func Test_jsonName(t *testing.T) {
+	type ranked struct {
+		Id    int
+		Rank  int64  `json:"rank"`
+		Total *int64 `json:"total,omitempty"`
+	}
+	typ := reflect.TypeOf(ranked{})
+	want := []string{"Id", "rank", "total"}
+	for i, name := range want {
+		if got := jsonName(typ.Field(i)); got != name {
+			t.Errorf("jsonName() = %s, want %s", got, name)
+		}
+	}
+}